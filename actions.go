@@ -1,7 +1,6 @@
 package validation
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 )
@@ -10,40 +9,73 @@ import (
 type Action func(interface{}) interface{}
 
 // List of actions
-type ActionMap map[string]Action
+// It is safe for concurrent use
+type ActionMap struct {
+	registry
+}
 
 // List of present actions
-var Actions = ActionMap{
-	"trim":  Trim,
-	"lower": Lower,
-	"upper": Upper,
-	"clear": Clear,
+var Actions = defaultActions()
+
+// Return build-in actions
+func defaultActions() *ActionMap {
+	a := &ActionMap{}
+	a.update(map[string]interface{}{
+		"trim":  Action(Trim),
+		"lower": Action(Lower),
+		"upper": Action(Upper),
+		"clear": Action(Clear),
+	})
+	return a
 }
 
 // Check what action exists
-func (a ActionMap) Has(name string) bool {
-	_, ok := Actions[name]
+func (a *ActionMap) Has(name string) bool {
+	_, ok := a.get(name)
 	return ok
 }
 
+// Get action by name
+func (a *ActionMap) Get(name string) (Action, bool) {
+	if action, ok := a.get(name); ok {
+		return action.(Action), true
+	}
+	return nil, false
+}
+
 // Add new action
-func (a ActionMap) Add(name string, action Action) {
-	a[name] = action
+func (a *ActionMap) Add(name string, action Action) {
+	a.update(map[string]interface{}{name: action})
+	resetCache()
 }
 
 // Trim spaces before and after string
 func Trim(value interface{}) interface{} {
-	return strings.TrimSpace(value.(string))
+	return mapString(value, strings.TrimSpace)
 }
 
 // Converts the string to lower case
 func Lower(value interface{}) interface{} {
-	return strings.ToLower(value.(string))
+	return mapString(value, strings.ToLower)
 }
 
 // Converts the string to upper case
 func Upper(value interface{}) interface{} {
-	return strings.ToUpper(value.(string))
+	return mapString(value, strings.ToUpper)
+}
+
+// Apply function to value of string kind, result has type of value
+// It panics if another types given
+func mapString(value interface{}, function func(string) string) interface{} {
+	if s, ok := value.(string); ok {
+		return function(s)
+	}
+
+	valueOf := reflect.ValueOf(value)
+	if valueOf.Kind() != reflect.String {
+		panic(errorWrongType)
+	}
+	return reflect.ValueOf(function(valueOf.String())).Convert(valueOf.Type()).Interface()
 }
 
 // Clear value by set default value
@@ -74,8 +106,3 @@ func Clear(value interface{}) interface{} { //todo maybe add Slice, Array, Map?
 //	}
 //	return value
 //}
-
-// Generate key for action
-func (a ActionMap) key() string {
-	return fmt.Sprintf("_%d_", len(a))
-}
@@ -0,0 +1,82 @@
+package validation
+
+import "testing"
+
+type testName string
+
+type testForm struct {
+	Name    string                 `valid:"trim|lower|min:3"`
+	Code    testName               `valid:"trim|upper"`
+	Comment *string                `valid:"trim"`
+	Count   float64                `valid:"clear"`
+	Tags    []string               `valid:"dive|trim|lower"`
+	Labels  map[string]string      `valid:"dive|trim"`
+	Extra   map[string]interface{} `valid:"dive|trim"`
+	Address struct {
+		City string `valid:"trim|required"`
+	}
+}
+
+func TestActions_Order(t *testing.T) {
+	var log string
+	first := Action(func(v interface{}) interface{} {
+		log += "1"
+		return v
+	})
+	second := Action(func(v interface{}) interface{} {
+		log += "2"
+		return v
+	})
+
+	for i := 0; i < 10; i++ {
+		ValidateValue("x", first, second, first)
+	}
+	if log != "121121121121121121121121121121" {
+		t.Error("Actions are performed in wrong order:", log)
+	}
+
+	// value is lowered before it is cleared
+	if errs := ValidateValue("ABC", "lower|clear|required"); len(errs) != 1 {
+		t.Error("Error performing actions in declaration order:", errs)
+	}
+}
+
+func TestValidateAndSanitize(t *testing.T) {
+	comment := "  note "
+	form := testForm{Name: " Bob ", Code: " ru ", Comment: &comment, Count: 5, Tags: []string{" A ", "b "},
+		Labels: map[string]string{"color": " red "}, Extra: map[string]interface{}{"note": " x "}}
+	form.Address.City = "  "
+
+	errs := ValidateAndSanitize(&form)
+
+	if form.Name != "bob" || form.Code != "RU" || comment != "note" || form.Count != 0 || form.Address.City != "" {
+		t.Errorf("Error writing back values: %+v", form)
+	}
+	if form.Tags[0] != "a" || form.Tags[1] != "b" {
+		t.Error("Error writing back elements:", form.Tags)
+	}
+	if form.Labels["color"] != "red" || form.Extra["note"] != "x" {
+		t.Error("Error writing back map elements:", form.Labels, form.Extra)
+	}
+	if len(errs) != 1 || errs["Address.City"] == nil {
+		t.Error("Error validating sanitized values:", errs)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Struct passed by value is not reported.")
+		}
+	}()
+	ValidateAndSanitize(form)
+}
+
+func TestSanitizeStruct(t *testing.T) {
+	form := testForm{Name: " A "}
+
+	if errs := SanitizeStruct(&form); !errs.Empty() {
+		t.Error("Unexpected errors:", errs)
+	}
+	if form.Name != "a" {
+		t.Error("Error writing back value:", form.Name)
+	}
+}
@@ -0,0 +1,70 @@
+package validation
+
+import "testing"
+
+type benchAddress struct {
+	City   string `valid:"required|min:2|max:100"`
+	Street string `valid:"required|max:255"`
+	Zip    string `valid:"regex:^[0-9]{5}$"`
+}
+
+type benchUser struct {
+	Name     string   `valid:"required|min:2|max:100"`
+	Email    string   `valid:"required|email"`
+	Age      int      `valid:"min:18|max:150"`
+	Role     string   `valid:"in:admin,user,guest"`
+	Password string   `valid:"password"`
+	Birthday string   `valid:"date_gte:02-01-2006,-150Y"`
+	Tags     []string `valid:"max:5|dive|alpha"`
+	Address  benchAddress
+}
+
+var benchValue = benchUser{
+	Name:     "John",
+	Email:    "john@example.com",
+	Age:      30,
+	Role:     "user",
+	Password: "abcABC0123",
+	Birthday: "01-12-1990",
+	Tags:     []string{"go", "validation"},
+	Address:  benchAddress{City: "Berlin", Street: "Unter den Linden", Zip: "10117"},
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if errs := ValidateStruct(benchValue); !errs.Empty() {
+			b.Fatal(errs.JSON())
+		}
+	}
+}
+
+func BenchmarkValidateStruct_Parallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ValidateStruct(benchValue)
+		}
+	})
+}
+
+func BenchmarkValidateStruct_Errors(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateStruct(benchUser{Name: "J", Age: 5, Tags: []string{"1"}})
+	}
+}
+
+func BenchmarkValidateValue(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateValue("john@example.com", "required|email|max:255")
+	}
+}
+
+func BenchmarkValidateValue_Regex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateValue("10117", "regex:^[0-9]{5}$")
+	}
+}
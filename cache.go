@@ -0,0 +1,313 @@
+package validation
+
+import (
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Prepared rules of value
+type plan struct {
+	wrappers []Wrapper
+	options  OptionList
+	actions  []Action
+	keys     *plan       // rules of map keys, nil if not specified
+	values   *plan       // rules of elements, nil if not specified
+	failure  interface{} // panic of preparing rules, it is raised on validation
+}
+
+// Struct field with prepared rules
+type fieldPlan struct {
+	field    reflect.StructField
+	name     string
+	index    []int
+	embedded bool
+	rules    string
+	plan     *plan
+}
+
+// Key of struct plans cache
+type structKey struct {
+	typeOf  reflect.Type
+	tags    string
+	rules   *ruleSnapshot // loaded rules what plan is built with
+	version uint64        // version of registries what plan is built with
+}
+
+// Key of rules plans cache
+type rulesKey struct {
+	rules   string
+	version uint64
+}
+
+// Numeric parameter parsed once
+// It keeps original representation for messages
+type number struct {
+	value float64
+	text  string
+	rat   *big.Rat // exact value, nil if text is not a decimal number
+}
+
+// Cached plans of engine
+type caches struct {
+	version uint64 // version of registries what plans are built with
+
+	// Plans of struct fields by type and tags
+	// Types come from code, but tags and loaded rules are parts of keys, so their number is limited too
+	structs boundedMap
+
+	// Plans of rules strings
+	// Rules come from tags, schemas of maps and configuration files, so their number is limited
+	rules boundedMap
+}
+
+// Map what stops storing new entries when limit is reached
+// It is safe for concurrent use
+type boundedMap struct {
+	m     sync.Map
+	count int64
+}
+
+// Maximum number of entries of each cache of plans, patterns and time zones
+const cacheLimit = 4096
+
+var (
+	// Version of registries, it is changed when validators, options or actions are added
+	registryVersion uint64
+
+	// Compiled patterns of regex rule
+	regexCache boundedMap
+
+	// Time zones of date rules
+	locationCache boundedMap
+)
+
+var emptyPlan = &plan{}
+
+// Returns original representation of number
+func (n number) String() string {
+	return n.text
+}
+
+// Invalidate cached plans of all engines
+// It is called when validators, options or actions are changed
+func resetCache() {
+	atomic.AddUint64(&registryVersion, 1)
+}
+
+// Clear plans built with outdated registries and return current version of registries
+// Version is a part of cache keys, so plans built during clearing are not used after it
+func (c *caches) sync() uint64 {
+	version := atomic.LoadUint64(&registryVersion)
+	if atomic.LoadUint64(&c.version) == version {
+		return version
+	}
+
+	c.structs.clear()
+	c.rules.clear()
+	atomic.StoreUint64(&c.version, version)
+	return version
+}
+
+// Store plan built with version of registries
+// Plans of outdated version are never loaded, so they are not stored
+func (c *caches) store(m *boundedMap, version uint64, key interface{}, value interface{}) {
+	if atomic.LoadUint64(&registryVersion) == version {
+		m.Store(key, value)
+	}
+}
+
+// Return value of key
+func (m *boundedMap) Load(key interface{}) (interface{}, bool) {
+	return m.m.Load(key)
+}
+
+// Store value of key if map is not full
+func (m *boundedMap) Store(key interface{}, value interface{}) {
+	if atomic.LoadInt64(&m.count) >= cacheLimit {
+		return
+	}
+	if _, loaded := m.m.LoadOrStore(key, value); !loaded {
+		atomic.AddInt64(&m.count, 1)
+	}
+}
+
+// Remove all entries
+func (m *boundedMap) clear() {
+	m.m.Range(func(key, value interface{}) bool {
+		if _, loaded := m.m.LoadAndDelete(key); loaded {
+			atomic.AddInt64(&m.count, -1)
+		}
+		return true
+	})
+}
+
+// Return plans of struct fields with rules from specified tags
+func (e *Engine) structPlan(typeOf reflect.Type, tags []string) []fieldPlan {
+	version := e.cache.sync()
+	rules := e.Rules.current()
+	key := structKey{typeOf: typeOf, tags: strings.Join(tags, ","), rules: rules, version: version}
+	if cached, ok := e.cache.structs.Load(key); ok {
+		return cached.([]fieldPlan)
+	}
+
+	var res []fieldPlan
+	for _, structField := range reflect.VisibleFields(typeOf) {
+		f := fieldPlan{
+			field:    structField,
+			name:     structField.Name,
+			index:    structField.Index,
+			embedded: structField.Anonymous,
+		}
+
+		for _, tag := range tags {
+			tagValue := rules.rules(tag, typeOf, structField.Name, structField.Tag.Get(tag))
+			if len(tagValue) > 0 && len(f.rules) > 0 {
+				f.rules = f.rules + "|" + tagValue
+			} else if len(tagValue) > 0 {
+				f.rules = tagValue
+			}
+		}
+
+		f.plan = e.compileRules(f.rules)
+		res = append(res, f)
+	}
+
+	e.cache.store(&e.cache.structs, version, key, res)
+	return res
+}
+
+// Return plan of rules
+// Plans of rules strings are cached
+func (e *Engine) compileRules(args ...interface{}) *plan {
+	rules, ok := joinRules(args)
+	if !ok {
+		return e.newPlan(args...)
+	}
+
+	version := e.cache.sync()
+	key := rulesKey{rules: rules, version: version}
+	if cached, ok := e.cache.rules.Load(key); ok {
+		return cached.(*plan)
+	}
+
+	p := e.newPlan(args...)
+	e.cache.store(&e.cache.rules, version, key, p)
+	return p
+}
+
+// Return rules string if all arguments are strings
+func joinRules(args []interface{}) (string, bool) {
+	if len(args) == 1 {
+		s, ok := args[0].(string)
+		return s, ok
+	}
+
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return "", false
+		}
+		parts[i] = s
+	}
+	return strings.Join(parts, "|"), true
+}
+
+// Prepare rules, split them by dive and precompile parameters
+func (e *Engine) newPlan(args ...interface{}) (p *plan) {
+	defer func() {
+		if r := recover(); r != nil {
+			p = &plan{failure: r}
+		}
+	}()
+
+	own, dive := splitDive(args...)
+	wrappers, options, actions := e.prepareRules(own...)
+
+	p = &plan{options: options, actions: actions}
+	for _, wrapper := range wrappers {
+		p.wrappers = append(p.wrappers, precompile(wrapper))
+	}
+	if len(dive.Keys) > 0 {
+		p.keys = e.newPlan(dive.Keys...)
+	}
+	if len(dive.Values) > 0 {
+		p.values = e.newPlan(dive.Values...)
+	}
+
+	return p
+}
+
+// Parse numeric parameters and compile patterns of build-in rules
+// Original parameters are kept for errors
+func precompile(wrapper Wrapper) Wrapper {
+	if len(wrapper.Name) == 0 || len(wrapper.Params) == 0 {
+		return wrapper
+	}
+
+	params := make([]interface{}, len(wrapper.Params))
+	copy(params, wrapper.Params)
+
+	if spec, ok := rulesParams[wrapper.Name]; ok && spec.Numeric {
+		for i, param := range params {
+			if s, ok := param.(string); ok {
+				if f, err := parseFloat(s); err == nil {
+					params[i] = number{value: f, text: s, rat: parseDecimal(s)}
+				}
+			}
+		}
+	}
+
+	if wrapper.Name == "regex" {
+		if s, ok := params[0].(string); ok {
+			if regex, err := compileRegex(s); err == nil {
+				params[0] = regex
+			}
+		}
+	}
+
+	if _, ok := contextValidators[wrapper.Name]; ok && len(params) > 2 {
+		if s, ok := params[2].(string); ok {
+			if loc, err := loadLocation(s); err == nil {
+				params[2] = loc
+			}
+		}
+	}
+
+	wrapper.params = params
+	return wrapper
+}
+
+// Return compiled pattern, patterns are cached
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Store(pattern, regex)
+	return regex, nil
+}
+
+// Return time zone by name, time zones are cached
+func loadLocation(name string) (*time.Location, error) {
+	if cached, ok := locationCache.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
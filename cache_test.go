@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestCompileRules(t *testing.T) {
+	p1 := defaultEngine().compileRules("required|max:255|regex:^[a-z]+$")
+	p2 := defaultEngine().compileRules("required|max:255|regex:^[a-z]+$")
+	if p1 != p2 {
+		t.Error("Error caching plan of rules.")
+	}
+
+	if _, ok := p1.wrappers[0].params[0].(number); !ok {
+		t.Error("Error parsing numeric params.")
+	}
+	if _, ok := p1.wrappers[1].params[0].(*regexp.Regexp); !ok {
+		t.Error("Error compiling regex params.")
+	}
+
+	if p := defaultEngine().compileRules("fake_rule"); p.failure == nil {
+		t.Error("Error preparing unknown rule.")
+	}
+}
+
+func TestStructPlan(t *testing.T) {
+	tags := []string{"valid"}
+	p1 := defaultEngine().structPlan(reflect.TypeOf(testOrder{}), tags)
+	p2 := defaultEngine().structPlan(reflect.TypeOf(testOrder{}), tags)
+	if &p1[0] != &p2[0] {
+		t.Error("Error caching struct plan.")
+	}
+}
+
+func TestResetCache(t *testing.T) {
+	s := struct {
+		Name string `valid:"test_reset"`
+	}{Name: "x"}
+
+	Validators.Add("test_reset", func(v interface{}, options OptionList, params ...interface{}) error {
+		return nil
+	})
+	if errs := ValidateStruct(s); !errs.Empty() {
+		t.Error("Unexpected errors.")
+	}
+
+	Validators.Add("test_reset", func(v interface{}, options OptionList, params ...interface{}) error {
+		return errors.New("error")
+	})
+	if errs := ValidateStruct(s); errs.Empty() {
+		t.Error("Replaced validator is not used.")
+	}
+}
+
+func TestCompileRules_Limit(t *testing.T) {
+	e := New()
+	for i := 0; i < cacheLimit+10; i++ {
+		e.compileRules(fmt.Sprintf("max:%d", i))
+	}
+	if e.cache.rules.count != cacheLimit {
+		t.Errorf("Wrong number of cached plans: %d", e.cache.rules.count)
+	}
+
+	p := e.compileRules("min:1")
+	if p == nil || p.wrappers[0].Name != "min" || p == e.compileRules("min:1") {
+		t.Error("Plan of rules is cached after limit.")
+	}
+
+	e.cache.rules.clear()
+	if e.cache.rules.count != 0 {
+		t.Errorf("Wrong number of plans after clearing: %d", e.cache.rules.count)
+	}
+}
+
+func TestCaches_Store(t *testing.T) {
+	e := New()
+	version := e.cache.sync()
+	e.structPlan(reflect.TypeOf(testOrder{}), []string{"valid"})
+	if e.cache.structs.count != 1 {
+		t.Errorf("Wrong number of cached struct plans: %d", e.cache.structs.count)
+	}
+
+	resetCache()
+	e.cache.store(&e.cache.structs, version, "outdated", []fieldPlan{})
+	if _, ok := e.cache.structs.Load("outdated"); ok {
+		t.Error("Plan of outdated registries is stored.")
+	}
+
+	e.cache.sync()
+	if e.cache.structs.count != 0 {
+		t.Errorf("Wrong number of struct plans after sync: %d", e.cache.structs.count)
+	}
+}
@@ -1,10 +1,470 @@
 package validation
 
-//todo нужен этот функционал?
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// Return map of right types casted
-//todo research this feature
-func Cast() map[string]interface{} {
-	res := make(map[string]interface{})
+// Layouts of dates what are used if field has no "date" rule
+var castLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// Fill struct fields by values of map converted to types of fields
+// Data can be map[string][]string like url.Values or map[string]interface{} like decoded JSON,
+// keys are field names by naming strategy, nested structs are filled by nested maps or by keys like "Address.City"
+// Time is parsed by layout of "date" rule of field
+// Struct must be passed by pointer, conversion errors are returned by paths of fields
+func Cast(data interface{}, s interface{}, tags ...string) ErrorMap {
+	return defaultEngine().Cast(data, s, tags...)
+}
+
+// Fill struct by values of map and validate it
+// Fields what are not converted are not validated
+func Bind(data interface{}, s interface{}, tags ...string) ErrorMap {
+	return defaultEngine().Bind(data, s, tags...)
+}
+
+// Fill struct by values of map and validate it with context
+// Context is passed to validators what use it, cancellation stops validation and its error is returned
+func BindCtx(ctx context.Context, data interface{}, s interface{}, tags ...string) (ErrorMap, error) {
+	return defaultEngine().BindCtx(ctx, data, s, tags...)
+}
+
+// Fill struct fields by values of map converted to types of fields
+func (e *Engine) Cast(data interface{}, s interface{}, tags ...string) (errs ErrorMap) {
+	errs = ErrorMap{}
+	if e.SafeMode {
+		defer func() {
+			if r := recover(); r != nil {
+				errs[""] = append(errs[""], configError("", r))
+			}
+		}()
+	}
+
+	valueOf := valueOf(s)
+	if valueOf.Kind() != reflect.Ptr || valueOf.Elem().Kind() != reflect.Struct {
+		panic(errorWrongType)
+	}
+
+	e.castStruct(errs, "", castData(data), valueOf.Elem(), e.structTags(tags))
+	return errs
+}
+
+// Fill struct by values of map and validate it
+func (e *Engine) Bind(data interface{}, s interface{}, tags ...string) ErrorMap {
+	errs := e.Cast(data, s, tags...)
+	if len(errs[""]) > 0 {
+		return errs
+	}
+
+	for path, list := range e.ValidateStruct(s, tags...) {
+		if _, ok := errs[path]; !ok {
+			errs[path] = list
+		}
+	}
+	return errs
+}
+
+// Fill struct by values of map and validate it with context
+func (e *Engine) BindCtx(ctx context.Context, data interface{}, s interface{}, tags ...string) (ErrorMap, error) {
+	c := *e
+	c.ctx = ctx
+	errs := c.Bind(data, s, tags...)
+	return errs, ctx.Err()
+}
+
+// Convert input map to nested map[string]interface{}
+// Keys like "Address.City" are converted to nested maps
+func castData(data interface{}) map[string]interface{} {
+	valueOf := indirect(valueOf(data))
+	if valueOf.Kind() != reflect.Map || valueOf.Type().Key().Kind() != reflect.String {
+		panic(errorWrongType)
+	}
+
+	res := make(map[string]interface{}, valueOf.Len())
+	for _, key := range valueOf.MapKeys() {
+		value := valueOf.MapIndex(key).Interface()
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = castData(nested)
+		}
+
+		path := strings.Split(key.String(), ".")
+		m := res
+		for _, name := range path[:len(path)-1] {
+			nested, ok := m[name].(map[string]interface{})
+			if !ok {
+				nested = make(map[string]interface{})
+				m[name] = nested
+			}
+			m = nested
+		}
+		m[path[len(path)-1]] = value
+	}
+
+	return res
+}
+
+// Fill fields of struct by values of map
+func (e *Engine) castStruct(errs ErrorMap, path string, data map[string]interface{}, valueOf reflect.Value, tags []string) {
+	for _, field := range reflect.VisibleFields(valueOf.Type()) {
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+
+		name, ok := e.externalName(field)
+		if !ok {
+			continue
+		}
+
+		raw, ok := data[name]
+		if !ok || raw == nil {
+			continue
+		}
+
+		value, err := valueOf.FieldByIndexErr(field.Index)
+		if err != nil {
+			value = fieldByIndexAlloc(valueOf, field.Index)
+		}
+
+		fieldPath := joinPath(path, name)
+		layout := e.castLayout(valueOf.Type(), field, tags)
+
+		if err := e.castValue(errs, fieldPath, raw, value, layout, tags); err != nil {
+			e.castFailed(errs, fieldPath, err)
+		}
+	}
+}
+
+// Return field by index path, nil embedded pointers are allocated
+func fieldByIndexAlloc(valueOf reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && valueOf.Kind() == reflect.Ptr {
+			if valueOf.IsNil() {
+				valueOf.Set(reflect.New(valueOf.Type().Elem()))
+			}
+			valueOf = valueOf.Elem()
+		}
+		valueOf = valueOf.Field(x)
+	}
+	return valueOf
+}
+
+// Return layout of "date" rule of field
+// Loaded rules of field are taken into account like by ValidateStruct
+func (e *Engine) castLayout(typeOf reflect.Type, field reflect.StructField, tags []string) string {
+	rules := e.Rules.current()
+	for _, tag := range tags {
+		for _, rule := range Parse(rules.rules(tag, typeOf, field.Name, field.Tag.Get(tag))) {
+			if rule.Name == "date" && len(rule.Params) > 0 {
+				if layout, ok := rule.Params[0].(string); ok {
+					return layout
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// Convert raw value to type of target and set it
+func (e *Engine) castValue(errs ErrorMap, path string, raw interface{}, target reflect.Value, layout string, tags []string) *FieldError {
+	for _, item := range []interface{}{raw, first(raw)} {
+		if item != nil && target.Kind() != reflect.Interface && reflect.TypeOf(item).AssignableTo(target.Type()) {
+			target.Set(reflect.ValueOf(item))
+			return nil
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		if blank(first(raw), target.Type().Elem()) {
+			target.Set(reflect.Zero(target.Type())) // blank value of optional field is not set
+			return nil
+		}
+		elem := reflect.New(target.Type().Elem())
+		if err := e.castValue(errs, path, raw, elem.Elem(), layout, tags); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+
+	case reflect.Interface:
+		if raw == nil {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		if !reflect.TypeOf(raw).AssignableTo(target.Type()) {
+			return castError(target, raw, layout)
+		}
+		target.Set(reflect.ValueOf(raw))
+		return nil
+
+	case reflect.Slice:
+		if target.Type().Elem().Kind() == reflect.Uint8 {
+			break // []byte is filled by string
+		}
+
+		items := castItems(raw)
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if err := e.castValue(errs, itemPath, item, slice.Index(i), layout, tags); err != nil {
+				e.castFailed(errs, itemPath, err)
+			}
+		}
+		target.Set(slice)
+		return nil
+
+	case reflect.Struct:
+		if target.Type() == timeType {
+			break
+		}
+
+		data, ok := first(raw).(map[string]interface{})
+		if !ok {
+			return castError(target, raw, layout)
+		}
+		e.castStruct(errs, path, data, target, tags)
+		return nil
+	}
+
+	value, ok := castScalar(first(raw), target.Type(), layout)
+	if !ok {
+		return castError(target, raw, layout)
+	}
+	target.Set(value)
+	return nil
+}
+
+// Return items of raw slice or raw value as single item
+func castItems(raw interface{}) []interface{} {
+	switch v := raw.(type) {
+	case []interface{}:
+		return v
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return items
+	default:
+		return []interface{}{raw}
+	}
+}
+
+// Return first item of raw slice or raw value
+func first(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case []string:
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0]
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		return v[0]
+	}
+
+	rawOf := reflect.ValueOf(raw)
+	if rawOf.Kind() == reflect.Slice && rawOf.Type().Elem().Kind() != reflect.Uint8 {
+		if rawOf.Len() == 0 {
+			return nil
+		}
+		return rawOf.Index(0).Interface()
+	}
+	return raw
+}
+
+// Convert scalar raw value to type
+func castScalar(raw interface{}, typeOf reflect.Type, layout string) (reflect.Value, bool) {
+	if raw == nil {
+		return reflect.Zero(typeOf), true
+	}
+
+	rawOf := reflect.ValueOf(raw)
+	if rawOf.Type().AssignableTo(typeOf) {
+		return rawOf, true
+	}
+
+	res := reflect.New(typeOf).Elem()
+	if n, ok := raw.(json.Number); ok {
+		if typeOf.Kind() == reflect.Bool {
+			return res, false
+		}
+		raw = string(n) // number of JSON decoded by UseNumber is parsed exactly
+		rawOf = reflect.ValueOf(raw)
+	}
+	s, isString := raw.(string)
+	if isString {
+		s = strings.TrimSpace(s)
+	}
+
+	switch {
+	case blank(raw, typeOf):
+		return res, true // empty input of form is zero value
+
+	case typeOf == timeType:
+		if t, ok := raw.(time.Time); ok {
+			return reflect.ValueOf(t), true
+		}
+		if !isString {
+			return res, false
+		}
+		layouts := castLayouts
+		if len(layout) > 0 {
+			layouts = []string{layout}
+		}
+		for _, l := range layouts {
+			if t, err := time.Parse(l, s); err == nil {
+				return reflect.ValueOf(t), true
+			}
+		}
+		return res, false
+
+	case typeOf.Kind() == reflect.String:
+		if isString {
+			res.SetString(raw.(string))
+		} else {
+			res.SetString(fmt.Sprint(raw))
+		}
+		return res, true
+
+	case typeOf.Kind() == reflect.Slice && typeOf.Elem().Kind() == reflect.Uint8 && isString:
+		res.SetBytes([]byte(raw.(string)))
+		return res, true
+
+	case typeOf.Kind() == reflect.Bool:
+		if b, ok := raw.(bool); ok {
+			res.SetBool(b)
+			return res, true
+		}
+		if s == "on" || s == "off" {
+			res.SetBool(s == "on")
+			return res, true
+		}
+		b, err := strconv.ParseBool(s)
+		res.SetBool(b)
+		return res, isString && err == nil
+
+	case isNumber(res):
+		return res, castNumber(raw, s, isString, res)
+	}
+
+	if rawOf.Type().ConvertibleTo(typeOf) && rawOf.Kind() == typeOf.Kind() {
+		return rawOf.Convert(typeOf), true
+	}
+	return res, false
+}
+
+// Check what raw value is blank string for boolean, number or time
+func blank(raw interface{}, typeOf reflect.Type) bool {
+	s, ok := raw.(string)
+	if !ok || len(strings.TrimSpace(s)) > 0 {
+		return false
+	}
+	return typeOf == timeType || typeOf.Kind() == reflect.Bool || isNumber(reflect.Zero(typeOf))
+}
+
+// Convert raw value and set it to numeric value
+// Integers in strings are parsed exactly
+func castNumber(raw interface{}, s string, isString bool, res reflect.Value) bool {
+	rawOf := reflect.ValueOf(raw)
+	if !isString && !isNumber(rawOf) {
+		return false
+	}
+
+	switch res.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if !isString {
+			f, err = rawOf.Convert(res.Type()).Float(), nil
+		}
+		if err != nil || res.OverflowFloat(f) {
+			return false
+		}
+		res.SetFloat(f)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if !isString {
+			i, err = castInt(rawOf)
+		}
+		if err != nil || res.OverflowInt(i) {
+			return false
+		}
+		res.SetInt(i)
+
+	default:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if !isString {
+			var i int64
+			i, err = castInt(rawOf)
+			if i < 0 {
+				return false
+			}
+			u = uint64(i)
+		}
+		if err != nil || res.OverflowUint(u) {
+			return false
+		}
+		res.SetUint(u)
+	}
+
+	return true
+}
+
+// Convert number to integer, floats must have no fractional part
+func castInt(value reflect.Value) (int64, error) {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := value.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, strconv.ErrRange
+		}
+		return int64(f), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Uint() > math.MaxInt64 {
+			return 0, strconv.ErrRange
+		}
+		return int64(value.Uint()), nil
+	default:
+		return value.Int(), nil
+	}
+}
+
+// Store conversion error by path
+func (e *Engine) castFailed(errs ErrorMap, path string, err *FieldError) {
+	res := e.fieldError(err, Wrapper{}, reflect.Value{})
+	res.Field = path
+	errs[path] = append(errs[path], res)
+}
+
+// Return error of conversion to type of target
+func castError(target reflect.Value, raw interface{}, layout string) *FieldError {
+	var err error
+	switch {
+	case target.Type() == timeType:
+		if len(layout) == 0 {
+			layout = castLayouts[0]
+		}
+		err = errorMessage("date", layout)
+	case target.Kind() == reflect.Bool:
+		err = errorMessage("bool")
+	case target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64:
+		err = errorMessage("float")
+	case isNumber(target):
+		err = errorMessage("int")
+	default:
+		err = errorMessage("type", target.Type().String())
+	}
+
+	res := err.(*FieldError)
+	res.Value = raw
 	return res
 }
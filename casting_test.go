@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type testBooking struct {
+	Name     string    `valid:"required|min:2"`
+	Guests   int       `valid:"required|gt:0"`
+	Price    float64   `valid:"gt:0"`
+	Paid     bool      `valid:""`
+	Date     time.Time `valid:"date:02.01.2006"`
+	Rooms    []uint    `valid:""`
+	Note     *string   `valid:""`
+	Customer testCustomer
+}
+
+func TestCast_Values(t *testing.T) {
+	data := url.Values{
+		"Name":           {"Bob"},
+		"Guests":         {" 3 "},
+		"Price":          {"10.5"},
+		"Paid":           {"on"},
+		"Date":           {"31.12.2021"},
+		"Rooms":          {"1", "2"},
+		"Note":           {"late"},
+		"Customer.Email": {"mail@example.com"},
+	}
+
+	var b testBooking
+	errs := Cast(data, &b)
+
+	if !errs.Empty() {
+		t.Error("Unexpected errors:", errs)
+	}
+	if b.Name != "Bob" || b.Guests != 3 || b.Price != 10.5 || !b.Paid || *b.Note != "late" {
+		t.Errorf("Error casting scalar values: %+v", b)
+	}
+	if !b.Date.Equal(time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Error casting date by layout of rule:", b.Date)
+	}
+	if len(b.Rooms) != 2 || b.Rooms[1] != 2 || b.Customer.Email != "mail@example.com" {
+		t.Errorf("Error casting slices and nested structs: %+v", b)
+	}
+}
+
+func TestCast_JSON(t *testing.T) {
+	data := map[string]interface{}{
+		"Guests":   float64(2),
+		"Price":    float64(7),
+		"Paid":     true,
+		"Rooms":    []interface{}{float64(5)},
+		"Customer": map[string]interface{}{"Name": "Ann"},
+	}
+
+	var b testBooking
+	if errs := Cast(data, &b); !errs.Empty() {
+		t.Error("Unexpected errors:", errs)
+	}
+	if b.Guests != 2 || b.Price != 7 || !b.Paid || b.Rooms[0] != 5 || b.Customer.Name != "Ann" {
+		t.Errorf("Error casting JSON values: %+v", b)
+	}
+}
+
+func TestCast_Errors(t *testing.T) {
+	data := map[string]interface{}{
+		"Guests": "three",
+		"Price":  "free",
+		"Paid":   "maybe",
+		"Date":   "2021-12-31",
+		"Rooms":  []interface{}{float64(1), float64(-1), 1.5},
+	}
+
+	var items = map[string]string{
+		"Guests":   "must be an integer number",
+		"Price":    "must be a float number",
+		"Paid":     "must be a boolean",
+		"Date":     "must be a valid date in 02.01.2006 format",
+		"Rooms[1]": "must be an integer number",
+		"Rooms[2]": "must be an integer number",
+	}
+
+	errs := Cast(data, &testBooking{})
+	if len(errs) != len(items) {
+		t.Error("Wrong errors:", errs)
+	}
+	for path, message := range items {
+		if len(errs[path]) != 1 || errs[path][0].Error() != message {
+			t.Errorf("Wrong error of %s: %v", path, errs[path])
+		}
+	}
+}
+
+func TestBind(t *testing.T) {
+	data := url.Values{"Name": {"B"}, "Guests": {"x"}}
+
+	errs := Bind(data, &testBooking{})
+
+	if len(errs["Guests"]) != 1 || errs["Guests"][0].(*FieldError).Rule != "int" {
+		t.Error("Conversion error is not returned:", errs["Guests"])
+	}
+	if len(errs["Name"]) != 1 || len(errs["Customer.Name"]) != 1 {
+		t.Error("Validation errors are not returned:", errs)
+	}
+}
+
+func TestCast_Blank(t *testing.T) {
+	data := url.Values{"Guests": {""}, "Price": {" "}, "Paid": {""}, "Date": {""}, "Note": {""}}
+
+	var b testBooking
+	if errs := Cast(data, &b); !errs.Empty() {
+		t.Error("Blank values are not casted:", errs)
+	}
+	if b.Guests != 0 || b.Price != 0 || b.Paid || !b.Date.IsZero() || b.Note == nil {
+		t.Errorf("Wrong values of blank strings: %+v", b)
+	}
+
+	var s struct {
+		Count *int
+	}
+	if errs := Cast(url.Values{"Count": {""}}, &s); !errs.Empty() || s.Count != nil {
+		t.Error("Blank value of optional field is set:", errs, s.Count)
+	}
+
+	if errs := Bind(url.Values{"Guests": {""}}, &testBooking{}); errs["Guests"][0].(*FieldError).Rule != "required" {
+		t.Error("Blank required value is not validated:", errs["Guests"])
+	}
+}
+
+func TestCast_Interface(t *testing.T) {
+	var s struct {
+		Any      interface{}
+		Stringer fmt.Stringer
+	}
+
+	errs := Cast(map[string]interface{}{"Any": "x", "Stringer": "y"}, &s)
+	if s.Any != "x" || s.Stringer != nil {
+		t.Errorf("Wrong values of interfaces: %+v", s)
+	}
+	if len(errs["Stringer"]) != 1 || errs["Stringer"][0].Error() != "must be a value of type fmt.Stringer" {
+		t.Error("Wrong error of not assignable value:", errs)
+	}
+
+	var items struct {
+		Items []interface{}
+	}
+	if errs := Cast(map[string]interface{}{"Items": []interface{}{nil, "a"}}, &items); !errs.Empty() || items.Items[0] != nil || items.Items[1] != "a" {
+		t.Error("Error casting nil:", errs, items.Items)
+	}
+}
+
+func TestCast_JSONNumber(t *testing.T) {
+	var s struct {
+		ID     int64
+		Price  float64
+		Code   string
+		Active bool
+	}
+
+	data := map[string]interface{}{"ID": json.Number("9007199254740993"), "Price": json.Number("1.5"), "Code": json.Number("12345678901234567890")}
+	if errs := Cast(data, &s); !errs.Empty() || s.ID != 9007199254740993 || s.Price != 1.5 || s.Code != "12345678901234567890" {
+		t.Errorf("Wrong values of JSON numbers: %v %+v", errs, s)
+	}
+
+	if errs := Cast(map[string]interface{}{"Active": json.Number("1"), "ID": json.Number("1.5")}, &s); len(errs) != 2 {
+		t.Error("Wrong errors of JSON numbers:", errs)
+	}
+}
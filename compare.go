@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// List of build-in validators what compare value with another field of the same struct
+var fieldValidators = map[string]Validator{
+	"eq_field":  eqField,
+	"ne_field":  neField,
+	"gt_field":  gtField,
+	"gte_field": gteField,
+	"lt_field":  ltField,
+	"lte_field": lteField,
+}
+
+// Value of field with struct what contains it
+type crossValue struct {
+	Value  reflect.Value
+	Struct reflect.Value
+}
+
+// Value must be equal to specified field
+// Numbers are compared by value, strings as is or as dates if layout is specified
+// Params: field path, optional date layout
+// Value kind: Number, String, time.Time
+// It panics if another types given
+func eqField(value interface{}, options OptionList, params ...interface{}) error {
+	return fieldComparison("eq_field", value.(crossValue), params, func(c int) bool { return c == 0 })
+}
+
+// Value must be not equal to specified field
+func neField(value interface{}, options OptionList, params ...interface{}) error {
+	return fieldComparison("ne_field", value.(crossValue), params, func(c int) bool { return c != 0 })
+}
+
+// Value must be greater than specified field
+func gtField(value interface{}, options OptionList, params ...interface{}) error {
+	return fieldComparison("gt_field", value.(crossValue), params, func(c int) bool { return c > 0 })
+}
+
+// Value must be greater or equal than specified field
+func gteField(value interface{}, options OptionList, params ...interface{}) error {
+	return fieldComparison("gte_field", value.(crossValue), params, func(c int) bool { return c >= 0 })
+}
+
+// Value must be lower than specified field
+func ltField(value interface{}, options OptionList, params ...interface{}) error {
+	return fieldComparison("lt_field", value.(crossValue), params, func(c int) bool { return c < 0 })
+}
+
+// Value must be lower or equal than specified field
+func lteField(value interface{}, options OptionList, params ...interface{}) error {
+	return fieldComparison("lte_field", value.(crossValue), params, func(c int) bool { return c <= 0 })
+}
+
+// Helper for creating validators what compare value with another field
+func fieldComparison(ruleName string, value crossValue, params []interface{}, fn func(int) bool) error {
+	other, ok := fieldByPath(value.Struct, parseString(params[0]))
+	if !ok {
+		panic(&ConfigError{Rule: ruleName, Err: fmt.Errorf("field \"%v\" not found", params[0])})
+	}
+
+	var layout string
+	if len(params) > 1 {
+		layout = parseString(params[1])
+	}
+
+	c, ok := compareValues(value.Value, other, layout)
+	if !ok || !fn(c) {
+		return errorMessage(ruleName, params...)
+	}
+	return nil
+}
+
+// Return field of struct by dotted path like "Period.Start"
+func fieldByPath(s reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		s = indirect(s)
+		switch {
+		case s.Kind() == reflect.Struct:
+			s = s.FieldByName(name)
+			if !s.IsValid() {
+				return reflect.Value{}, false
+			}
+		case s.Kind() == reflect.Map && s.Type().Key().Kind() == reflect.String:
+			s = s.MapIndex(reflect.ValueOf(name).Convert(s.Type().Key()))
+			if !s.IsValid() {
+				return reflect.Value{}, true // keys of maps are optional
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return s, true
+}
+
+// Compare two values, it returns -1, 0 or +1
+// It returns false if values can't be compared, for example invalid date
+func compareValues(a reflect.Value, b reflect.Value, layout string) (int, bool) {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	switch {
+	case a.Type() == timeType && b.Type() == timeType:
+		return compareTimes(a.Interface().(time.Time), b.Interface().(time.Time)), true
+
+	case isNumber(a) && isNumber(b):
+		x, y := size(a), size(b)
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		if len(layout) == 0 {
+			return strings.Compare(a.String(), b.String()), true
+		}
+		x, err := time.Parse(layout, a.String())
+		if err != nil {
+			return 0, false
+		}
+		y, err := time.Parse(layout, b.String())
+		if err != nil {
+			return 0, false
+		}
+		return compareTimes(x, y), true
+	}
+
+	panic(errorWrongType)
+}
+
+// Compare two times, it returns -1, 0 or +1
+func compareTimes(x time.Time, y time.Time) int {
+	switch {
+	case x.Before(y):
+		return -1
+	case x.After(y):
+		return 1
+	}
+	return 0
+}
+
+// Check what value kind is number
+func isNumber(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Dereference pointers and interfaces, nil pointer returns invalid value
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
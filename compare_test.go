@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+type testPeriod struct {
+	Start string
+	End   string `valid:"gt_field:Start,2006-01-02"`
+}
+
+type testCompare struct {
+	Password       string `valid:"eq_field:PasswordRepeat"`
+	PasswordRepeat string
+	Min            int
+	Max            int    `valid:"gte_field:Min"`
+	Deadline       string `valid:"gte_field:Period.End,2006-01-02"`
+	Period         testPeriod
+	Created        time.Time
+	Updated        *time.Time `valid:"gte_field:Created"`
+	Nick           string     `valid:"ne_field:Password"`
+}
+
+func TestFieldComparison(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	valid := testCompare{
+		Password:       "secret",
+		PasswordRepeat: "secret",
+		Min:            1,
+		Max:            1,
+		Period:         testPeriod{Start: "2020-01-01", End: "2020-01-02"},
+		Deadline:       "2020-01-02",
+		Created:        before,
+		Updated:        &now,
+		Nick:           "nick",
+	}
+	if errs := ValidateStruct(valid); !errs.Empty() {
+		t.Errorf("Unexpected errors %s.", errs.JSON())
+	}
+
+	notValid := testCompare{
+		Password:       "secret",
+		PasswordRepeat: "secret2",
+		Min:            2,
+		Max:            1,
+		Period:         testPeriod{Start: "2020-01-01", End: "2019-01-02"},
+		Deadline:       "2018-01-01",
+		Created:        now,
+		Updated:        &before,
+		Nick:           "secret",
+	}
+	errs := ValidateStruct(notValid)
+	for _, name := range []string{"Password", "Max", "Period.End", "Deadline", "Updated", "Nick"} {
+		if errs[name].Empty() {
+			t.Errorf("Expected errors for %s.", name)
+		}
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	var items = []struct {
+		A, B   interface{}
+		Layout string
+		Result int
+		Ok     bool
+	}{
+		{A: 1, B: 2.5, Result: -1, Ok: true},
+		{A: uint8(3), B: int64(3), Result: 0, Ok: true},
+		{A: "b", B: "a", Result: 1, Ok: true},
+		{A: "02-01-2020", B: "01-02-2020", Layout: "02-01-2006", Result: -1, Ok: true},
+		{A: "fake", B: "01-02-2020", Layout: "02-01-2006", Ok: false},
+	}
+
+	for _, item := range items {
+		res, ok := compareValues(valueOf(item.A), valueOf(item.B), item.Layout)
+		if res != item.Result || ok != item.Ok {
+			t.Errorf("Wrong comparison of «%v» and «%v».", item.A, item.B)
+		}
+	}
+}
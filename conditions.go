@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Option what is turned on if condition on other fields of struct is met
+type conditionalOption struct {
+	Option    Option
+	Condition Validator
+}
+
+// List of build-in conditional options
+// Condition returns nil if it is met
+var conditionalOptions = map[string]conditionalOption{
+	"required_if":          {Option: Required, Condition: fieldIn},
+	"required_unless":      {Option: Required, Condition: fieldNotIn},
+	"required_with":        {Option: Required, Condition: anyPresent},
+	"required_with_all":    {Option: Required, Condition: allPresent},
+	"required_without":     {Option: Required, Condition: anyAbsent},
+	"required_without_all": {Option: Required, Condition: allAbsent},
+	"excluded_if":          {Option: Excluded, Condition: fieldIn},
+	"excluded_unless":      {Option: Excluded, Condition: fieldNotIn},
+	"excluded_with":        {Option: Excluded, Condition: anyPresent},
+	"excluded_without":     {Option: Excluded, Condition: anyAbsent},
+}
+
+var errConditionNotMet = fmt.Errorf("condition not met")
+
+// Field specified by first parameter must be equal to one of next parameters
+// Example: "required_if:DeliveryMethod,courier,post"
+func fieldIn(value interface{}, options OptionList, params ...interface{}) error {
+	field := conditionField(value.(crossValue), params[0])
+	if !field.IsValid() {
+		return errConditionNotMet
+	}
+
+	s := parseString(field.Interface())
+	for _, param := range params[1:] {
+		if s == parseString(param) {
+			return nil
+		}
+	}
+	return errConditionNotMet
+}
+
+// Field specified by first parameter must not be equal to any of next parameters
+func fieldNotIn(value interface{}, options OptionList, params ...interface{}) error {
+	if fieldIn(value, options, params...) == nil {
+		return errConditionNotMet
+	}
+	return nil
+}
+
+// Any of specified fields must be not empty
+// Example: "required_with:Email,Phone"
+func anyPresent(value interface{}, options OptionList, params ...interface{}) error {
+	for _, param := range params {
+		if present(conditionField(value.(crossValue), param)) {
+			return nil
+		}
+	}
+	return errConditionNotMet
+}
+
+// All of specified fields must be not empty
+func allPresent(value interface{}, options OptionList, params ...interface{}) error {
+	for _, param := range params {
+		if !present(conditionField(value.(crossValue), param)) {
+			return errConditionNotMet
+		}
+	}
+	return nil
+}
+
+// Any of specified fields must be empty
+func anyAbsent(value interface{}, options OptionList, params ...interface{}) error {
+	if allPresent(value, options, params...) == nil {
+		return errConditionNotMet
+	}
+	return nil
+}
+
+// All of specified fields must be empty
+func allAbsent(value interface{}, options OptionList, params ...interface{}) error {
+	if anyPresent(value, options, params...) == nil {
+		return errConditionNotMet
+	}
+	return nil
+}
+
+// Return field of struct for condition, nil pointer returns invalid value
+// It panics if field not found
+func conditionField(value crossValue, name interface{}) reflect.Value {
+	field, ok := fieldByPath(value.Struct, parseString(name))
+	if !ok {
+		panic(&ConfigError{Err: fmt.Errorf("field \"%v\" not found", name)})
+	}
+	return indirect(field)
+}
+
+// Check what value is not empty
+func present(value reflect.Value) bool {
+	return value.IsValid() && empty(value, OptionList{}) != nil
+}
@@ -0,0 +1,72 @@
+package validation
+
+import "testing"
+
+type testDelivery struct {
+	DeliveryMethod  string
+	ShippingAddress string `valid:"required_if:DeliveryMethod,courier,post"`
+	Email           string
+	Phone           string `valid:"required_without:Email"`
+	Fax             string `valid:"required_without_all:Email,Phone"`
+	Comment         string `valid:"excluded_if:DeliveryMethod,pickup|max:10"`
+	Coupon          string `valid:"excluded_with:Discount"`
+	Discount        int
+}
+
+func TestConditionalOptions(t *testing.T) {
+	var items = []struct {
+		Value  testDelivery
+		Errors []string
+	}{
+		{
+			Value:  testDelivery{DeliveryMethod: "pickup", Email: "a@example.com"},
+			Errors: []string{},
+		},
+		{
+			Value:  testDelivery{DeliveryMethod: "courier", Phone: "123"},
+			Errors: []string{"ShippingAddress"},
+		},
+		{
+			Value:  testDelivery{DeliveryMethod: "pickup"},
+			Errors: []string{"Phone", "Fax"},
+		},
+		{
+			Value:  testDelivery{DeliveryMethod: "pickup", Email: "a@example.com", Comment: "ring", Coupon: "X", Discount: 5},
+			Errors: []string{"Comment", "Coupon"},
+		},
+		{
+			Value:  testDelivery{DeliveryMethod: "post", ShippingAddress: "Street", Email: "a@example.com", Comment: "too long comment"},
+			Errors: []string{"Comment"},
+		},
+	}
+
+	for i, item := range items {
+		errs := ValidateStruct(item.Value)
+		if len(errs) != len(item.Errors) {
+			t.Errorf("Case %d: expected errors for %v, got %s.", i, item.Errors, errs.JSON())
+		}
+		for _, name := range item.Errors {
+			if errs[name].Empty() {
+				t.Errorf("Case %d: expected errors for %s.", i, name)
+			}
+		}
+	}
+}
+
+func TestConditionalOptions_Functional(t *testing.T) {
+	d := testDelivery{DeliveryMethod: "courier"}
+
+	errs := ValidateField(d, d.ShippingAddress, RequiredIf("DeliveryMethod", "courier"), Max(100))
+	if len(errs) != 1 {
+		t.Fatal("Expected required error.")
+	}
+
+	fieldErr := errs[0].(*FieldError)
+	if fieldErr.Rule != "required_if" || fieldErr.Error() != "is required when DeliveryMethod is courier" {
+		t.Errorf("Wrong error %+v.", fieldErr)
+	}
+
+	if errs := ValidateField(d, d.Email, RequiredUnless("DeliveryMethod", "courier")); !errs.Empty() {
+		t.Error("Unexpected required error.")
+	}
+}
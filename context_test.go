@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testTenantKey struct{}
+
+type testSignup struct {
+	Login string `valid:"required|unique_in_tenant"`
+	Email string `valid:"email"`
+}
+
+func TestValidateStructCtx(t *testing.T) {
+	e := New()
+	e.Validators.AddContext("unique_in_tenant", func(ctx context.Context, v interface{}, options OptionList, params ...interface{}) error {
+		if ctx.Value(testTenantKey{}) != "acme" {
+			return errors.New("wrong tenant")
+		}
+		if v.(testSignup).Login == "taken" {
+			return errors.New("must be unique")
+		}
+		return nil
+	})
+
+	s := testSignup{Login: "taken", Email: "wrong"}
+	ctx := context.WithValue(context.Background(), testTenantKey{}, "acme")
+
+	errs, err := e.ValidateStructCtx(ctx, s)
+	if err != nil || len(errs) != 2 || errs["Login"][0].Error() != "must be unique" {
+		t.Error("Error validating with context:", errs, err)
+	}
+
+	if errs := e.ValidateStruct(s); errs["Login"][0].Error() != "wrong tenant" {
+		t.Error("Validator is not called with background context:", errs)
+	}
+}
+
+func TestValidateValueCtx_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+	check := func(ctx context.Context, v interface{}, options OptionList, params ...interface{}) error {
+		calls++
+		cancel()
+		return ctx.Err()
+	}
+	next := func(v interface{}, options OptionList, params ...interface{}) error {
+		calls++
+		return errors.New("error")
+	}
+
+	errs, err := ValidateValueCtx(ctx, "x", check, next, "email")
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Cancellation is not returned:", err)
+	}
+	if len(errs) != 0 || calls != 1 {
+		t.Error("Rules are not stopped by cancellation:", errs, calls)
+	}
+
+	errs, err = ValidateValueCtx(ctx, "x", "email")
+	if len(errs) != 0 || err == nil {
+		t.Error("Canceled context is not checked:", errs, err)
+	}
+}
+
+func TestBindCtx(t *testing.T) {
+	e := New()
+	e.Validators.AddContext("unique_in_tenant", func(ctx context.Context, v interface{}, options OptionList, params ...interface{}) error {
+		if ctx.Value(testTenantKey{}) != "acme" {
+			return errors.New("wrong tenant")
+		}
+		return nil
+	})
+
+	var s testSignup
+	ctx := context.WithValue(context.Background(), testTenantKey{}, "acme")
+	if errs, err := e.BindCtx(ctx, map[string]interface{}{"Login": "al"}, &s); err != nil || !errs.Empty() || s.Login != "al" {
+		t.Error("Error binding with context:", errs, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := e.BindCtx(ctx, map[string]interface{}{"Login": "al"}, &s); !errors.Is(err, context.Canceled) {
+		t.Error("Cancellation is not returned:", err)
+	}
+}
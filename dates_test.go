@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetDateAt(t *testing.T) {
+	// wednesday
+	now := time.Date(2021, 3, 17, 15, 30, 0, 0, time.UTC)
+
+	var items = []struct {
+		Placeholder DatePlaceholder
+		Date        time.Time
+	}{
+		{Placeholder: "now", Date: now},
+		{Placeholder: "today", Date: time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{Placeholder: "tomorrow", Date: time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{Placeholder: "start_of_week", Date: time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{Placeholder: "end_of_week", Date: time.Date(2021, 3, 21, 23, 59, 59, 999999999, time.UTC)},
+		{Placeholder: "start_of_month", Date: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Placeholder: "end_of_month", Date: time.Date(2021, 3, 31, 23, 59, 59, 999999999, time.UTC)},
+		{Placeholder: "end_of_year", Date: time.Date(2021, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{Placeholder: "-18Y", Date: time.Date(2003, 3, 17, 15, 30, 0, 0, time.UTC)},
+		{Placeholder: "+2W", Date: time.Date(2021, 3, 31, 15, 30, 0, 0, time.UTC)},
+		{Placeholder: "-1Y +2M -3h", Date: time.Date(2020, 5, 17, 12, 30, 0, 0, time.UTC)},
+		{Placeholder: "today -1M", Date: time.Date(2021, 2, 17, 0, 0, 0, 0, time.UTC)},
+		{Placeholder: "start_of_month +1M -1D", Date: time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, item := range items {
+		date, err := GetDateAt(item.Placeholder, now)
+		if err != nil || !date.Equal(item.Date) {
+			t.Errorf("Wrong date %v of %s: %v", date, item.Placeholder, err)
+		}
+	}
+
+	for _, placeholder := range []DatePlaceholder{"", "later", "-1X", "today today", "-1D today", "01-12-2019"} {
+		if _, err := GetDateAt(placeholder, now); err == nil {
+			t.Errorf("Placeholder %s is not reported.", placeholder)
+		}
+	}
+}
+
+func TestGetDateAt_Months(t *testing.T) {
+	var items = []struct {
+		Now         time.Time
+		Placeholder DatePlaceholder
+		Date        time.Time
+	}{
+		{Now: time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), Placeholder: "end_of_month -1M", Date: time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC)},
+		{Now: time.Date(2023, 3, 31, 12, 0, 0, 0, time.UTC), Placeholder: "end_of_month -1M", Date: time.Date(2023, 2, 28, 23, 59, 59, 999999999, time.UTC)},
+		{Now: time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), Placeholder: "end_of_month +2M", Date: time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC)},
+		{Now: time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), Placeholder: "start_of_month -1M", Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Now: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), Placeholder: "end_of_year -1Y", Date: time.Date(2023, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{Now: time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), Placeholder: "start_of_week +1M", Date: time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{Now: time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), Placeholder: "today -1M", Date: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{Now: time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC), Placeholder: "now -1M", Date: time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC)},
+		{Now: time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), Placeholder: "+1M -1D", Date: time.Date(2024, 4, 29, 12, 0, 0, 0, time.UTC)},
+		{Now: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), Placeholder: "today -18Y", Date: time.Date(2006, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{Now: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), Placeholder: "+4Y", Date: time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, item := range items {
+		date, err := GetDateAt(item.Placeholder, item.Now)
+		if err != nil || !date.Equal(item.Date) {
+			t.Errorf("Wrong date %v of %s at %v: %v", date, item.Placeholder, item.Now, err)
+		}
+	}
+}
+
+func TestDate_Clock(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2021, 3, 17, 23, 30, 0, 0, time.UTC)
+	}
+
+	e := New()
+	e.Clock = clock
+
+	// it is 18 March already in Berlin
+	if errs := e.ValidateValue("2021-03-18", "date_lte:2006-01-02,today"); len(errs) != 1 {
+		t.Error("Clock of engine is not used:", errs)
+	}
+	if errs := e.ValidateValue("2021-03-18", "date_lte:2006-01-02,today,Europe/Berlin"); len(errs) != 0 {
+		t.Error("Time zone of rule is not used:", errs)
+	}
+	if errs := e.ValidateValue(time.Date(2021, 3, 18, 0, 30, 0, 0, time.UTC), "date_lt:2006-01-02,tomorrow"); len(errs) != 1 {
+		t.Error("Time value is not compared by date:", errs)
+	}
+
+	ctx := WithClock(context.Background(), func() time.Time {
+		return time.Date(2021, 3, 19, 12, 0, 0, 0, time.UTC)
+	})
+	if errs, _ := e.ValidateValueCtx(ctx, "2021-03-18", "date_lt:2006-01-02,today"); len(errs) != 0 {
+		t.Error("Clock of context is not used:", errs)
+	}
+
+	s := struct {
+		Birthday time.Time `valid:"date_lte:2006-01-02,-18Y,America/New_York"`
+	}{Birthday: time.Date(2003, 3, 18, 1, 0, 0, 0, time.UTC)}
+
+	// it is 17 March 2003 in New York
+	if errs := e.ValidateStruct(s); len(errs) != 0 {
+		t.Error("Time value is not converted to time zone of rule:", errs)
+	}
+
+	if syntaxErrs := TestSyntax("date_gte:2006-01-02,-18Y,Mars/Olympus"); len(syntaxErrs) != 1 {
+		t.Error("Unknown time zone is not reported.")
+	}
+}
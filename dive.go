@@ -0,0 +1,167 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Rule after which all rules are applied to each element of slice, array or map
+const diveRule = "dive"
+
+// Rules between these rules are applied to each key of map
+const (
+	keysRule    = "keys"
+	endKeysRule = "endkeys"
+)
+
+// Rules for each element of slice, array or map
+// Keys rules are applied only to map keys, Values rules to slice, array and map elements
+// Example of tag: "max:10|dive|keys|alpha|endkeys|required|email"
+type Dive struct {
+	Keys   []interface{}
+	Values []interface{}
+}
+
+// Check what dive has no rules
+func (d Dive) Empty() bool {
+	return len(d.Keys) == 0 && len(d.Values) == 0
+}
+
+// Split arguments into value rules and elements rules
+// All rules and arguments after "dive" are applied to elements
+func splitDive(args ...interface{}) ([]interface{}, Dive) {
+	var own []interface{}
+	var dive Dive
+
+	for i, arg := range args {
+		switch arg.(type) {
+
+		case string:
+			rules := Parse(arg.(string))
+			for j, rule := range rules {
+				if rule.Name == diveRule {
+					rest := make([]interface{}, 0, len(rules)-j-1+len(args)-i-1)
+					for _, r := range rules[j+1:] {
+						rest = append(rest, r)
+					}
+					dive.add(splitKeys(append(rest, args[i+1:]...)))
+					return own, dive
+				}
+				own = append(own, rule)
+			}
+
+		case Rule:
+			if arg.(Rule).Name == diveRule {
+				dive.add(splitKeys(args[i+1:]))
+				return own, dive
+			}
+			own = append(own, arg)
+
+		case Dive:
+			dive.add(arg.(Dive))
+
+		default:
+			own = append(own, arg)
+		}
+	}
+
+	return own, dive
+}
+
+// Split arguments after dive into keys and values rules
+func splitKeys(args []interface{}) Dive {
+	var dive Dive
+	var inKeys bool
+
+	for i, arg := range args {
+		rule, _ := arg.(Rule)
+		switch {
+		case i == 0 && rule.Name == keysRule:
+			inKeys = true
+		case inKeys && rule.Name == endKeysRule:
+			inKeys = false
+		case inKeys:
+			dive.Keys = append(dive.Keys, arg)
+		default:
+			dive.Values = append(dive.Values, arg)
+		}
+	}
+
+	return dive
+}
+
+// Append rules of another dive
+func (d *Dive) add(dive Dive) {
+	d.Keys = append(d.Keys, dive.Keys...)
+	d.Values = append(d.Values, dive.Values...)
+}
+
+// Validate each element of slice, array or map by dive rules of plan
+// Errors are stored by paths like "Emails[2]" and "Tags[color]"
+// Elements what are structs are validated recursively even without dive rules
+func (e *Engine) validateElements(errs ErrorMap, path string, parent interface{}, value reflect.Value, tags []string, p *plan) {
+	value = indirect(value)
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if p.keys == nil && p.values == nil && !hasStructs(value.Type().Elem()) {
+			return
+		}
+	default:
+		return
+	}
+
+	values := p.values
+	if values == nil {
+		values = emptyPlan
+	}
+
+	if value.Kind() == reflect.Map {
+		for _, key := range value.MapKeys() {
+			elemPath := fmt.Sprintf("%s[%v]", path, key)
+			if p.keys != nil {
+				e.validateRules(errs, elemPath, parent, key, p.keys)
+			}
+			e.validateElement(errs, elemPath, parent, value, key, tags, values)
+		}
+		return
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		e.validateField(errs, elemPath, parent, value.Index(i), tags, values)
+	}
+}
+
+// Validate element of map by key
+// Map elements are not addressable, so in write back mode element is validated as copy and stored back
+func (e *Engine) validateElement(errs ErrorMap, path string, parent interface{}, value reflect.Value, key reflect.Value, tags []string, p *plan) {
+	elem := value.MapIndex(key)
+	if !e.writeBack || !value.CanInterface() {
+		e.validateField(errs, path, parent, elem, tags, p)
+		return
+	}
+
+	copied := reflect.New(elem.Type()).Elem()
+	copied.Set(elem)
+	e.validateField(errs, path, parent, copied, tags, p)
+	value.SetMapIndex(key, copied)
+}
+
+// Check what values of type can contain structs for recursive validation
+func hasStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return hasStructs(t.Elem())
+	case reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
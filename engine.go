@@ -0,0 +1,294 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+)
+
+// Validation engine with own registries of validators, actions, options, messages and own settings
+// Package functions like ValidateStruct use default engine built on package variables
+type Engine struct {
+	Validators *ValidatorMap
+	Actions    *ActionMap
+	Options    *OptionMap
+	Catalogs   *CatalogMap
+	Tag        string
+	Locale     string
+	SafeMode   bool
+	FieldName  NameFunc
+	Clock      ClockFunc
+	Rules      *RuleSet
+
+	cache        *caches
+	writeBack    bool            // values changed by actions are stored to struct
+	sanitizeOnly bool            // actions are performed without validation
+	dynamic      bool            // values are decoded documents, wrong types of values are validation errors
+	ctx          context.Context // context of validation, it is nil if not specified
+	visited      map[visit]bool  // structs walked by current call, it is nil outside of call
+}
+
+// Caches of package functions
+var defaultCache = &caches{}
+
+// Create new engine with build-in rules and messages only
+func New() *Engine {
+	return &Engine{
+		Validators: &ValidatorMap{},
+		Actions:    defaultActions(),
+		Options:    defaultOptions(),
+		Catalogs:   defaultCatalogs(),
+		Tag:        "valid",
+		Locale:     fallbackLocale,
+		cache:      &caches{},
+	}
+}
+
+// Return engine built on current package variables
+// Registries are shared with package, settings are copied
+func Default() *Engine {
+	return defaultEngine()
+}
+
+// Return engine built on package variables
+func defaultEngine() *Engine {
+	return &Engine{
+		Validators: Validators,
+		Actions:    Actions,
+		Options:    Options,
+		Catalogs:   Catalogs,
+		Tag:        defaultTag,
+		Locale:     Locale,
+		SafeMode:   SafeMode,
+		FieldName:  FieldName,
+		Clock:      Clock,
+		Rules:      Rules,
+		cache:      defaultCache,
+	}
+}
+
+// Validate structure
+// Nested structs and pointers to structs are validated recursively,
+// their errors are stored by dotted paths like "Customer.Email"
+func (e *Engine) ValidateStruct(s interface{}, tags ...string) (errs ErrorMap) {
+	e = e.walker()
+	errs = ErrorMap{}
+	if e.SafeMode {
+		defer func() {
+			if r := recover(); r != nil {
+				errs[""] = append(errs[""], configError("", r))
+			}
+		}()
+	}
+	e.validateStruct(errs, "", s, tags)
+	return errs
+}
+
+// Validate structure and store values changed by actions like "trim" to its fields
+// Struct must be passed by pointer
+func (e *Engine) ValidateAndSanitize(s interface{}, tags ...string) ErrorMap {
+	sanitizer := *e
+	sanitizer.writeBack = true
+	return sanitizer.ValidateStruct(s, tags...)
+}
+
+// Perform actions like "trim" on fields of structure and store changed values without validation
+// Struct must be passed by pointer
+// Only configuration errors are returned in safe mode
+func (e *Engine) SanitizeStruct(s interface{}, tags ...string) ErrorMap {
+	sanitizer := *e
+	sanitizer.writeBack = true
+	sanitizer.sanitizeOnly = true
+	return sanitizer.ValidateStruct(s, tags...)
+}
+
+// Validate scalar value
+// Errors of elements validated by Dive rules are appended to the value's errors
+func (e *Engine) ValidateValue(value interface{}, args ...interface{}) ErrorList {
+	return e.ValidateField(value, value, args...)
+}
+
+// Validate structure with context
+// Context is passed to validators what use it, cancellation stops validation and its error is returned
+func (e *Engine) ValidateStructCtx(ctx context.Context, s interface{}, tags ...string) (ErrorMap, error) {
+	c := *e
+	c.ctx = ctx
+	errs := c.ValidateStruct(s, tags...)
+	return errs, ctx.Err()
+}
+
+// Validate scalar value with context
+func (e *Engine) ValidateValueCtx(ctx context.Context, value interface{}, args ...interface{}) (ErrorList, error) {
+	c := *e
+	c.ctx = ctx
+	errs := c.ValidateValue(value, args...)
+	return errs, ctx.Err()
+}
+
+// Validate value of struct field
+// Struct is used by rules what depend on other fields like "eq_field" or "required_if"
+func (e *Engine) ValidateField(s interface{}, value interface{}, args ...interface{}) ErrorList {
+	e = e.walker()
+	errs := ErrorMap{}
+	p := e.compileRules(args...)
+	if e.validateRules(errs, "", s, value, p) {
+		e.validateElements(errs, "", s, valueOf(value), nil, p)
+	}
+	return errs.list()
+}
+
+// Return struct fields with rules from specified tags
+// Fields of embedded structs are promoted to the struct level the way Go promotes them
+func (e *Engine) InspectStruct(s interface{}, tags ...string) (res []Field) {
+	valueOf := reflect.Indirect(valueOf(s))
+	typeOf := valueOf.Type()
+
+	if typeOf.Kind() != reflect.Struct {
+		panic(errorWrongType)
+	}
+
+	for _, f := range e.structPlan(typeOf, e.structTags(tags)) {
+		value, err := valueOf.FieldByIndexErr(f.index)
+		if err != nil {
+			continue // field of nil embedded pointer
+		}
+
+		res = append(res, Field{Name: f.name, Value: value, Rules: f.rules, Embedded: f.embedded})
+	}
+
+	return res
+}
+
+// Return engine what tracks structs visited by one call
+// Structs reached again by pointers are skipped, so cycles like n.Next = n are walked once
+func (e *Engine) walker() *Engine {
+	if e.visited != nil {
+		return e
+	}
+	c := *e
+	c.visited = make(map[visit]bool)
+	return &c
+}
+
+// Return specified tags or default tag of engine
+func (e *Engine) structTags(tags []string) []string {
+	if len(tags) == 0 {
+		return []string{e.Tag}
+	}
+	return tags
+}
+
+// Return name of field for input and output
+// It returns false if field is not named
+func (e *Engine) externalName(field reflect.StructField) (string, bool) {
+	if e.FieldName == nil {
+		return field.Name, true
+	}
+
+	switch name := e.FieldName(field); name {
+	case "":
+		return field.Name, true
+	case "-":
+		return "", false
+	default:
+		return name, true
+	}
+}
+
+// Return context of validation
+// Clock of engine is used if context has no clock
+func (e *Engine) context() context.Context {
+	ctx := e.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if e.Clock != nil && ctx.Value(clockKey{}) == nil {
+		ctx = WithClock(ctx, e.Clock)
+	}
+	return ctx
+}
+
+// Check what context of validation is canceled
+func (e *Engine) canceled() bool {
+	return e.ctx != nil && e.ctx.Err() != nil
+}
+
+// Get validator what uses context if it exists
+// Build-in validators can not be replaced
+func (e *Engine) contextValidator(name string) (ContextValidator, bool) {
+	if validator, ok := contextValidators[name]; ok {
+		return validator, true
+	}
+	if _, ok := validators[name]; ok {
+		return nil, false
+	}
+	if _, ok := fieldValidators[name]; ok {
+		return nil, false
+	}
+	return e.Validators.GetContext(name)
+}
+
+// Get validator of rule if it exists
+func (e *Engine) validator(name string) (Validator, bool) {
+	if validator, ok := validators[name]; ok {
+		return validator, ok
+	}
+
+	if validator, ok := fieldValidators[name]; ok {
+		return validator, ok
+	}
+
+	if validator, ok := e.Validators.Get(name); ok {
+		return validator, ok
+	}
+
+	return nil, false
+}
+
+// Get option of rule if it exists
+func (e *Engine) option(name string) (Option, bool) {
+	return e.Options.Get(name)
+}
+
+// Get action of rule if it exists
+func (e *Engine) action(name string) (Action, bool) {
+	if action, ok := e.Actions.Get(name); ok {
+		return action, ok
+	}
+
+	return nil, false
+}
+
+// Check what rule exists in registries of engine
+func (e *Engine) exists(r Rule) bool {
+	if _, ok := e.validator(r.Name); ok {
+		return true
+	}
+
+	if _, ok := e.option(r.Name); ok {
+		return true
+	}
+
+	if _, ok := e.action(r.Name); ok {
+		return true
+	}
+
+	_, ok := r.Condition()
+	return ok
+}
+
+// Convert error of validator to field error
+// Messages of build-in rules are rendered by catalogs and locale of engine
+func (e *Engine) fieldError(err error, wrapper Wrapper, value reflect.Value) *FieldError {
+	res := fieldError(err, wrapper, value)
+	if message, ok := e.message(e.Locale, res.Rule); ok && res.Err == nil {
+		res.Message = replace(message, res.Params)
+	}
+	res.engine = e
+	return res
+}
+
+// Return message of rule by locale
+// Messages of loaded rule set take precedence over catalogs
+func (e *Engine) message(locale string, rule string) (string, bool) {
+	return e.Catalogs.message(locale, rule, e.Rules.current().localeMessages())
+}
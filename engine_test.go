@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEngine(t *testing.T) {
+	e := New()
+	e.Validators.Add("engine_only", func(v interface{}, options OptionList, params ...interface{}) error {
+		return errors.New("engine error")
+	})
+	e.Catalogs.Add("en", map[string]string{"max": "too long"})
+	e.Tag = "on_create"
+
+	s := struct {
+		Name string `valid:"fake_rule" on_create:"max:3"`
+		Code string `on_create:"engine_only"`
+	}{Name: "abcd", Code: "x"}
+
+	errs := e.ValidateStruct(s)
+	if len(errs) != 2 || errs["Name"][0].Error() != "too long" || errs["Code"][0].Error() != "engine error" {
+		t.Error("Error validating by engine:", errs)
+	}
+
+	if Validators.Has("engine_only") {
+		t.Error("Validator of engine is added to package registry.")
+	}
+	if message, _ := Messages.Get("max"); message == "too long" {
+		t.Error("Message of engine is added to package catalog.")
+	}
+	if errs := ValidateValue("abcd", "max:3"); errs[0].Error() == "too long" {
+		t.Error("Package function uses message of engine.")
+	}
+	if syntaxErrs := e.TestSyntax("engine_only"); len(syntaxErrs) != 0 {
+		t.Error("Engine rule is not found by engine.")
+	}
+	if syntaxErrs := TestSyntax("engine_only"); len(syntaxErrs) != 1 {
+		t.Error("Engine rule is found by package.")
+	}
+}
+
+func TestEngineSafeMode(t *testing.T) {
+	e := New()
+	e.SafeMode = true
+
+	errs := e.ValidateValue("x", "fake_rule")
+	if len(errs) != 1 || len(errs.ConfigErrors()) != 1 {
+		t.Error("Error returning configuration error by engine:", errs)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Safe mode of engine is used by package.")
+		}
+	}()
+	ValidateValue("x", "fake_rule")
+}
+
+func TestEngineLocale(t *testing.T) {
+	e := New()
+	e.Locale = "ru"
+
+	errs := e.ValidateValue("", "required")
+	if errs[0].Error() != renderMessage("ru", "required", nil) {
+		t.Error("Error rendering message in locale of engine:", errs)
+	}
+}
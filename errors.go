@@ -1,6 +1,40 @@
 package validation
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var (
+	// Value type is not allowed for rule
+	ErrWrongType = errors.New(errorWrongType)
+
+	// Rule is not found in validators, options and actions
+	ErrRuleNotFound = errors.New("rule not found")
+)
+
+// Error of validation configuration: unknown rule, wrong value type, malformed parameters, etc.
+// In SafeMode it is returned within errors instead of panic
+type ConfigError struct {
+	Field string
+	Rule  string
+	Err   error
+}
+
+// Validation error with failed rule, its params, field path and value
+type FieldError struct {
+	Field   string        `json:"field,omitempty"`
+	Rule    string        `json:"code,omitempty"`
+	Params  []interface{} `json:"params,omitempty"`
+	Value   interface{}   `json:"-"`
+	Message string        `json:"message"`
+	Err     error         `json:"-"`
+
+	engine *Engine // engine what made the error, its messages are used by Translate
+}
 
 // The value's validation errors list
 type ErrorList []error
@@ -8,6 +42,110 @@ type ErrorList []error
 // The struct's validation errors map
 type ErrorMap map[string]ErrorList
 
+// Returns rendered message
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// Returns error of custom validator
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Returns description of configuration error
+func (e *ConfigError) Error() string {
+	var res string
+	if len(e.Field) > 0 {
+		res = e.Field + ": "
+	}
+	if len(e.Rule) > 0 {
+		res += fmt.Sprintf("rule \"%s\": ", e.Rule)
+	}
+	return res + e.Err.Error()
+}
+
+// Returns cause of configuration error
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Make configuration error from recovered panic
+func configError(rule string, r interface{}) *ConfigError {
+	var res ConfigError
+	switch v := r.(type) {
+	case *ConfigError:
+		res = *v
+	case error:
+		res.Err = v
+	default:
+		if v == errorWrongType {
+			res.Err = ErrWrongType
+		} else {
+			res.Err = fmt.Errorf("%v", v)
+		}
+	}
+
+	if len(res.Rule) == 0 {
+		res.Rule = rule
+	}
+	return &res
+}
+
+// Returns copy of error with message in specified locale
+// Messages of engine what made the error are used. Message is kept if catalogs have no message
+// for the rule or error is returned by custom validator
+func (e *FieldError) Translate(locale string) *FieldError {
+	res := *e
+	if len(e.Rule) == 0 || e.Err != nil {
+		return &res
+	}
+
+	message, ok := Catalogs.message(locale, e.Rule)
+	if e.engine != nil {
+		message, ok = e.engine.message(locale, e.Rule)
+	}
+	if ok {
+		res.Message = replace(message, e.Params)
+	}
+	return &res
+}
+
+// Make field error from validator's error
+// Errors of custom validators are wrapped with rule name and params
+func fieldError(err error, wrapper Wrapper, value reflect.Value) *FieldError {
+	var res FieldError
+	if fieldErr, ok := err.(*FieldError); ok {
+		res = *fieldErr
+		switch {
+		case wrapper.params == nil:
+		case res.Rule == wrapper.Name:
+			res.Params = wrapper.Params // original params instead of precompiled
+		default:
+			res.Params = plainParams(res.Params)
+		}
+	} else {
+		res = FieldError{Rule: wrapper.Name, Params: wrapper.Params, Message: err.Error(), Err: err}
+	}
+
+	if value.IsValid() && value.CanInterface() {
+		res.Value = value.Interface()
+	}
+
+	return &res
+}
+
+// Return params with precompiled numbers replaced by their original representation
+func plainParams(params []interface{}) []interface{} {
+	res := make([]interface{}, len(params))
+	for i, param := range params {
+		if n, ok := param.(number); ok {
+			param = n.text
+		}
+		res[i] = param
+	}
+	return res
+}
+
 // Checks that the errors list is empty
 func (e ErrorList) Empty() bool {
 	return len(e) == 0
@@ -28,6 +166,55 @@ func (e ErrorList) JSON() string {
 	return string(b)
 }
 
+// Returns configuration errors of list
+func (e ErrorList) ConfigErrors() []*ConfigError {
+	var res []*ConfigError
+	for _, err := range e {
+		var configErr *ConfigError
+		if errors.As(err, &configErr) {
+			res = append(res, configErr)
+		}
+	}
+	return res
+}
+
+// Returns errors with messages in specified locale
+// Locales are searched by chain like "de-AT" -> "de" -> "en"
+func (e ErrorList) Translate(locale string) ErrorList {
+	if e == nil {
+		return nil
+	}
+	res := make(ErrorList, len(e))
+	for i, err := range e {
+		if fieldErr, ok := err.(*FieldError); ok {
+			res[i] = fieldErr.Translate(locale)
+		} else {
+			res[i] = err
+		}
+	}
+	return res
+}
+
+// Returns list of field errors
+// Errors of another types are converted to field errors with message only
+func (e ErrorList) FieldErrors() []*FieldError {
+	res := make([]*FieldError, len(e))
+	for i, err := range e {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			fieldErr = &FieldError{Message: err.Error(), Err: err}
+		}
+		res[i] = fieldErr
+	}
+	return res
+}
+
+// Returns JSON representation of ErrorList with codes and params of rules
+func (e ErrorList) DetailedJSON() string {
+	b, _ := json.Marshal(e.FieldErrors())
+	return string(b)
+}
+
 // Checks that the errors map is empty
 func (e ErrorMap) Empty() bool {
 	for _, item := range e {
@@ -43,3 +230,45 @@ func (e ErrorMap) JSON() string {
 	b, _ := json.Marshal(e)
 	return string(b)
 }
+
+// Returns configuration errors of all fields sorted by field
+func (e ErrorMap) ConfigErrors() []*ConfigError {
+	return e.list().ConfigErrors()
+}
+
+// Returns errors with messages in specified locale
+func (e ErrorMap) Translate(locale string) ErrorMap {
+	res := make(ErrorMap, len(e))
+	for key, item := range e {
+		res[key] = item.Translate(locale)
+	}
+	return res
+}
+
+// Returns JSON representation of ErrorMap with codes and params of rules
+func (e ErrorMap) DetailedJSON() string {
+	res := make(map[string][]*FieldError, len(e))
+	for key, item := range e {
+		res[key] = item.FieldErrors()
+	}
+	b, _ := json.Marshal(res)
+	return string(b)
+}
+
+// Returns all errors of map as list
+// Errors of empty key are first, others are sorted by key
+func (e ErrorMap) list() ErrorList {
+	var keys []string
+	for key := range e {
+		if len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	res := append(ErrorList(nil), e[""]...)
+	for _, key := range keys {
+		res = append(res, e[key]...)
+	}
+	return res
+}
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -46,3 +47,62 @@ func TestErrorMap_JSON(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestFieldError(t *testing.T) {
+	errs := ValidateStruct(struct {
+		Name string `valid:"min:5"`
+	}{Name: "abc"})
+
+	var fieldErr *FieldError
+	if !errors.As(errs["Name"][0], &fieldErr) {
+		t.Fatal("Error converting to FieldError.")
+	}
+	if fieldErr.Rule != "min" || fieldErr.Field != "Name" || fieldErr.Value != "abc" || fieldErr.Params[0] != "5" {
+		t.Errorf("Wrong field error %+v.", fieldErr)
+	}
+}
+
+func TestFieldError_Custom(t *testing.T) {
+	custom := errors.New("custom")
+	Validators.Add("test_custom", func(v interface{}, options OptionList, params ...interface{}) error {
+		return custom
+	})
+
+	errs := ValidateValue("abc", "test_custom:x")
+
+	var fieldErr *FieldError
+	if !errors.As(errs[0], &fieldErr) || fieldErr.Rule != "test_custom" || !errors.Is(errs[0], custom) {
+		t.Error("Error wrapping custom validator error.")
+	}
+}
+
+func TestErrorList_DetailedJSON(t *testing.T) {
+	r := "[{\"code\":\"min\",\"params\":[\"2\"],\"message\":\"must be greater or equal of 2\"},{\"message\":\"error\"}]"
+	e := ErrorList{errorMessage("min", "2"), errors.New("error")}
+
+	if e.DetailedJSON() != r {
+		t.Error(e.DetailedJSON())
+	}
+}
+
+func TestFieldError_Params(t *testing.T) {
+	wrapper := precompile(Wrapper{Name: "min", Params: []interface{}{"18"}})
+	err := fieldError(errorMessage("numeric", wrapper.params...), wrapper, reflect.Value{})
+	if len(err.Params) != 1 || err.Params[0] != "18" {
+		t.Errorf("Precompiled params are returned: %#v", err.Params)
+	}
+}
+
+func TestErrorMap_List(t *testing.T) {
+	root := make(ErrorList, 1, 4)
+	root[0] = errors.New("root")
+	e := ErrorMap{"": root, "a": {errors.New("a")}, "b": {errors.New("b")}}
+
+	first := e.list()
+	e["a"] = ErrorList{errors.New("c")}
+	e.list()
+
+	if len(e[""]) != 1 || first.JSON() != `["root","a","b"]` {
+		t.Errorf("Errors of previous list are changed: %s", first.JSON())
+	}
+}
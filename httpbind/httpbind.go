@@ -0,0 +1,255 @@
+// Package httpbind fills structs by HTTP requests, validates them and writes error responses
+package httpbind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/qvp/validation"
+)
+
+// Binder of requests with own engine and settings
+// Scenario tag of request method is used for validation in addition to tag of engine
+type Binder struct {
+	Engine    *validation.Engine // default engine of validation package is used if nil
+	Scenarios map[string]string  // scenario tags by HTTP method, package ones are used if nil
+	MaxMemory int64              // maximum bytes of multipart form stored in memory
+	MaxBytes  int64              // maximum bytes of request body
+}
+
+// Error of request decoding with HTTP status of response
+type Error struct {
+	Status int
+	Err    error
+}
+
+// Key of bound value in request context
+type contextKey struct{}
+
+// Default scenario tags by HTTP method
+var Scenarios = map[string]string{
+	http.MethodPost:  "on_create",
+	http.MethodPut:   "on_update",
+	http.MethodPatch: "on_update",
+}
+
+// Default maximum bytes of multipart form stored in memory
+var MaxMemory int64 = 32 << 20
+
+// Default maximum bytes of request body
+var MaxBytes int64 = 10 << 20
+
+// Fill struct by request and validate it
+// Query string is used for all methods, body is decoded by its content type:
+// JSON, url encoded or multipart form. Files of multipart form are stored to fields
+// of type *multipart.FileHeader or []*multipart.FileHeader. Fields of JSON requests are named
+// by "json" tag if engine has no naming strategy, numbers are decoded exactly.
+// Struct must be passed by pointer. It returns *Error if request can not be decoded.
+// Validation uses context of request, error of context is returned if request is canceled.
+func Bind(r *http.Request, s interface{}) (validation.ErrorMap, error) {
+	return defaultBinder().Bind(r, s)
+}
+
+// Fill struct by request, validate it and write error response if it fails
+// It returns false if response is written
+func Handle(w http.ResponseWriter, r *http.Request, s interface{}) bool {
+	return defaultBinder().Handle(w, r, s)
+}
+
+// Create middleware what binds request to new value and stores it in request context
+// Requests what are not valid are not passed to next handler
+func Middleware(newValue func() interface{}) func(http.Handler) http.Handler {
+	return defaultBinder().Middleware(newValue)
+}
+
+// Return value bound by middleware
+func Value(r *http.Request) interface{} {
+	return r.Context().Value(contextKey{})
+}
+
+// Write response of decoding or validation error
+// Validation errors are written as JSON with status 422, configuration errors with status 500.
+// Decoding errors are written with their status and its text, details are not exposed to clients
+func WriteError(w http.ResponseWriter, errs validation.ErrorMap, err error) {
+	status := http.StatusUnprocessableEntity
+	var decodeErr *Error
+	switch {
+	case errors.As(err, &decodeErr):
+		status = decodeErr.Status
+	case err != nil || len(errs.ConfigErrors()) > 0:
+		status = http.StatusInternalServerError
+	}
+	if status != http.StatusUnprocessableEntity {
+		errs = validation.ErrorMap{"": validation.ErrorList{errors.New(http.StatusText(status))}}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	io.WriteString(w, errs.JSON())
+}
+
+// Fill struct by request and validate it
+func (b *Binder) Bind(r *http.Request, s interface{}) (validation.ErrorMap, error) {
+	data, isJSON, err := b.decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := b.engine()
+	if isJSON && engine.FieldName == nil {
+		c := *engine
+		c.FieldName = validation.TagName("json")
+		engine = &c
+	}
+	return engine.BindCtx(r.Context(), data, s, b.tags(engine, r.Method)...)
+}
+
+// Fill struct by request, validate it and write error response if it fails
+func (b *Binder) Handle(w http.ResponseWriter, r *http.Request, s interface{}) bool {
+	errs, err := b.Bind(r, s)
+	if err != nil || !errs.Empty() {
+		WriteError(w, errs, err)
+		return false
+	}
+	return true
+}
+
+// Create middleware what binds request to new value and stores it in request context
+func (b *Binder) Middleware(newValue func() interface{}) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := newValue()
+			if !b.Handle(w, r, value) {
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, value)))
+		})
+	}
+}
+
+// Returns description of decoding error
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Returns cause of decoding error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Return binder built on package variables
+func defaultBinder() *Binder {
+	return &Binder{Scenarios: Scenarios, MaxMemory: MaxMemory, MaxBytes: MaxBytes}
+}
+
+// Return engine of binder
+func (b *Binder) engine() *validation.Engine {
+	if b.Engine != nil {
+		return b.Engine
+	}
+	return validation.Default()
+}
+
+// Return validation tags of request method
+func (b *Binder) tags(engine *validation.Engine, method string) []string {
+	scenarios := b.Scenarios
+	if scenarios == nil {
+		scenarios = Scenarios
+	}
+	if scenario, ok := scenarios[method]; ok {
+		return []string{engine.Tag, scenario}
+	}
+	return []string{engine.Tag}
+}
+
+// Decode query string and body of request to map
+// It returns true if body is JSON
+func (b *Binder) decode(r *http.Request) (map[string]interface{}, bool, error) {
+	data := make(map[string]interface{})
+	for key, values := range r.URL.Query() {
+		data[key] = values
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return data, false, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if len(contentType) == 0 {
+		return data, false, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false, &Error{Status: http.StatusUnsupportedMediaType, Err: err}
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, b.maxBytes())
+
+	switch mediaType {
+	case "application/json":
+		var body map[string]interface{}
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil && err != io.EOF {
+			return nil, false, bodyError(err)
+		}
+		for key, value := range body {
+			data[key] = value
+		}
+		return data, true, nil
+
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, false, bodyError(err)
+		}
+		for key, values := range r.PostForm {
+			data[key] = values
+		}
+
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(b.maxMemory()); err != nil {
+			return nil, false, bodyError(err)
+		}
+		for key, values := range r.MultipartForm.Value {
+			data[key] = values
+		}
+		for key, files := range r.MultipartForm.File {
+			data[key] = files
+		}
+
+	default:
+		return nil, false, &Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("unsupported content type " + mediaType)}
+	}
+
+	return data, false, nil
+}
+
+// Make error of body decoding, too large body has its own status
+func bodyError(err error) *Error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &Error{Status: http.StatusRequestEntityTooLarge, Err: err}
+	}
+	return &Error{Status: http.StatusBadRequest, Err: err}
+}
+
+// Return maximum bytes of multipart form stored in memory
+func (b *Binder) maxMemory() int64 {
+	if b.MaxMemory > 0 {
+		return b.MaxMemory
+	}
+	return MaxMemory
+}
+
+// Return maximum bytes of request body
+func (b *Binder) maxBytes() int64 {
+	if b.MaxBytes > 0 {
+		return b.MaxBytes
+	}
+	return MaxBytes
+}
@@ -0,0 +1,227 @@
+package httpbind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/qvp/validation"
+)
+
+type testUser struct {
+	Name     string `valid:"required|max:10"`
+	Age      int    `valid:"gte_field:MinAge"`
+	MinAge   int
+	Email    string `on_update:"ignore" valid:"required|email"`
+	Password string `on_create:"required|min:8"`
+	Tags     []string
+	Address  struct {
+		City string `valid:"required"`
+	}
+	Avatar *multipart.FileHeader
+}
+
+func TestBind_JSON(t *testing.T) {
+	body := `{"Name":"Bob","Age":30,"Email":"bob@example.com","Password":"secret123","Tags":["a","b"],"Address":{"City":"Paris"}}`
+	r := httptest.NewRequest(http.MethodPost, "/users?MinAge=18", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	var user testUser
+	errs, err := Bind(r, &user)
+	if err != nil || !errs.Empty() {
+		t.Fatalf("Wrong errors: %v %v", errs, err)
+	}
+	if user.Name != "Bob" || user.Age != 30 || user.MinAge != 18 || len(user.Tags) != 2 || user.Address.City != "Paris" {
+		t.Errorf("Wrong bound value: %+v", user)
+	}
+}
+
+func TestBind_Scenarios(t *testing.T) {
+	var items = []struct {
+		Method string
+		Fields []string
+	}{
+		{Method: http.MethodPost, Fields: []string{"Email", "Password"}},
+		{Method: http.MethodPut, Fields: nil},
+		{Method: http.MethodGet, Fields: []string{"Email"}},
+	}
+
+	for _, item := range items {
+		r := httptest.NewRequest(item.Method, "/users?Name=Bob&Address.City=Paris", nil)
+		errs, err := Bind(r, &testUser{})
+		if err != nil || len(errs) != len(item.Fields) {
+			t.Errorf("Wrong errors of %s: %v %v", item.Method, errs, err)
+			continue
+		}
+		for _, field := range item.Fields {
+			if _, ok := errs[field]; !ok {
+				t.Errorf("Error of %s not found for %s: %v", field, item.Method, errs)
+			}
+		}
+	}
+}
+
+func TestBind_Form(t *testing.T) {
+	form := url.Values{"Name": {"Bob"}, "Age": {"x"}, "Email": {"bob@example.com"}, "Tags": {"a", "b"}, "Address.City": {"Paris"}}
+	r := httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var user testUser
+	errs, err := Bind(r, &user)
+	if err != nil || len(errs) != 1 || errs["Age"][0].Error() != "must be an integer number" {
+		t.Errorf("Wrong errors: %v %v", errs, err)
+	}
+	if user.Name != "Bob" || len(user.Tags) != 2 || user.Address.City != "Paris" {
+		t.Errorf("Wrong bound value: %+v", user)
+	}
+}
+
+func TestBind_Multipart(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("Name", "Bob")
+	writer.WriteField("Address.City", "Paris")
+	file, _ := writer.CreateFormFile("Avatar", "avatar.png")
+	file.Write([]byte("png"))
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPut, "/users/1", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var user testUser
+	errs, err := Bind(r, &user)
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("Wrong errors: %v %v", errs, err)
+	}
+	if user.Name != "Bob" || user.Avatar == nil || user.Avatar.Filename != "avatar.png" {
+		t.Errorf("Wrong bound value: %+v", user)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	var items = []struct {
+		ContentType string
+		Body        string
+		Status      int
+		Response    string
+	}{
+		{ContentType: "application/json", Body: `{"Name":"Bob","Email":"bob@example.com","Address":{"City":"Paris"}}`, Status: http.StatusOK},
+		{ContentType: "application/json", Body: `{"Name":"Bob Robertson","Address":{"City":"Paris"}}`, Status: http.StatusUnprocessableEntity, Response: `{"Name":["must be lower or equal of 10"]}`},
+		{ContentType: "application/json", Body: `{"Name":`, Status: http.StatusBadRequest, Response: `{"":["Bad Request"]}`},
+		{ContentType: "application/json", Body: `[1]`, Status: http.StatusBadRequest},
+		{ContentType: "text/plain", Body: `Bob`, Status: http.StatusUnsupportedMediaType},
+	}
+
+	handler := Middleware(func() interface{} { return &testUser{} })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, ok := Value(r).(*testUser); !ok || user.Name != "Bob" {
+			t.Errorf("Wrong value in context: %v", Value(r))
+		}
+	}))
+
+	for _, item := range items {
+		r := httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(item.Body))
+		r.Header.Set("Content-Type", item.ContentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != item.Status {
+			t.Errorf("Wrong status of «%s»: %d %s", item.Body, w.Code, w.Body)
+		}
+		if len(item.Response) > 0 && w.Body.String() != item.Response {
+			t.Errorf("Wrong response of «%s»: %s", item.Body, w.Body)
+		}
+	}
+}
+
+func TestBinder_Engine(t *testing.T) {
+	engine := validation.New()
+	engine.Tag = "input"
+	engine.FieldName = validation.TagName("json")
+	binder := &Binder{Engine: engine, Scenarios: map[string]string{http.MethodPost: "on_signup"}}
+
+	type signup struct {
+		Login string `json:"login" input:"required|min:3"`
+		Terms bool   `json:"terms" on_signup:"required|accepted"`
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"login":"al"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	errs, err := binder.Bind(r, &signup{})
+	if err != nil || len(errs) != 2 || len(errs["login"]) != 1 || len(errs["terms"]) != 1 {
+		t.Errorf("Wrong errors: %v %v", errs, err)
+	}
+}
+
+type testOrder struct {
+	ID       int64   `json:"id" valid:"required"`
+	Customer string  `json:"customer_name" valid:"required|min:3"`
+	Amount   float64 `json:"amount" valid:"gt:0"`
+	Internal string  `json:"-" valid:"required"`
+}
+
+func TestBind_JSONTags(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":9007199254740993,"customer_name":"Al","amount":0.5,"Internal":"x"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	var order testOrder
+	errs, err := Bind(r, &order)
+	if err != nil || len(errs) != 2 || len(errs["customer_name"]) != 1 || len(errs["Internal"]) != 1 {
+		t.Errorf("Wrong errors: %v %v", errs, err)
+	}
+	if order.ID != 9007199254740993 || order.Customer != "Al" || order.Amount != 0.5 || order.Internal != "" {
+		t.Errorf("Wrong bound value: %+v", order)
+	}
+}
+
+func TestBind_MaxBytes(t *testing.T) {
+	binder := &Binder{MaxBytes: 10}
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"customer_name":"Alice Smith"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	if binder.Handle(w, r, &testOrder{}) || w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Wrong response of large body: %d %s", w.Code, w.Body)
+	}
+}
+
+type testContextKey struct{}
+
+type testProject struct {
+	Tenant string `valid:"required|test_tenant"`
+}
+
+func TestBind_Context(t *testing.T) {
+	engine := validation.New()
+	engine.Validators.AddContext("test_tenant", func(ctx context.Context, value interface{}, options validation.OptionList, params ...interface{}) error {
+		if value.(*testProject).Tenant != ctx.Value(testContextKey{}) {
+			return errors.New("must be a tenant of request")
+		}
+		return nil
+	})
+	binder := &Binder{Engine: engine}
+
+	ctx := context.WithValue(context.Background(), testContextKey{}, "acme")
+	r := httptest.NewRequest(http.MethodPost, "/projects?Tenant=acme", nil).WithContext(ctx)
+	if errs, err := binder.Bind(r, &testProject{}); err != nil || !errs.Empty() {
+		t.Errorf("Context of request is not used: %v %v", errs, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	r = r.WithContext(ctx)
+	if _, err := binder.Bind(r, &testProject{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wrong error of canceled request: %v", err)
+	}
+
+	handler := binder.Middleware(func() interface{} { return &testProject{} })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Canceled request is passed to handler.")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+}
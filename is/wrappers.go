@@ -14,6 +14,14 @@ func Lazy(params ...interface{}) validation.Option {
 	return validation.Lazy
 }
 
+func Excluded(params ...interface{}) validation.Option {
+	return validation.Excluded
+}
+
+func Numeric(params ...interface{}) validation.Option {
+	return validation.Numeric
+}
+
 func Empty(params ...interface{}) validation.Rule {
 	return validation.Rule{Name: "empty", Params: params}
 }
@@ -118,6 +126,18 @@ func Max(params ...interface{}) validation.Rule {
 	return validation.Rule{Name: "max", Params: params}
 }
 
+func MinValue(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "min_value", Params: params}
+}
+
+func MaxValue(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "max_value", Params: params}
+}
+
+func Between(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "between", Params: params}
+}
+
 func Len(params ...interface{}) validation.Rule {
 	return validation.Rule{Name: "len", Params: params}
 }
@@ -181,3 +201,77 @@ func HasKeys(params ...interface{}) validation.Rule {
 func HasOnlyKeys(params ...interface{}) validation.Rule {
 	return validation.Rule{Name: "has_only_keys", Params: params}
 }
+
+func EqField(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "eq_field", Params: params}
+}
+
+func NeField(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "ne_field", Params: params}
+}
+
+func GtField(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "gt_field", Params: params}
+}
+
+func GteField(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "gte_field", Params: params}
+}
+
+func LtField(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "lt_field", Params: params}
+}
+
+func LteField(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "lte_field", Params: params}
+}
+
+func RequiredIf(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "required_if", Params: params}
+}
+
+func RequiredUnless(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "required_unless", Params: params}
+}
+
+func RequiredWith(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "required_with", Params: params}
+}
+
+func RequiredWithAll(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "required_with_all", Params: params}
+}
+
+func RequiredWithout(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "required_without", Params: params}
+}
+
+func RequiredWithoutAll(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "required_without_all", Params: params}
+}
+
+func ExcludedIf(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "excluded_if", Params: params}
+}
+
+func ExcludedUnless(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "excluded_unless", Params: params}
+}
+
+func ExcludedWith(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "excluded_with", Params: params}
+}
+
+func ExcludedWithout(params ...interface{}) validation.Rule {
+	return validation.Rule{Name: "excluded_without", Params: params}
+}
+
+// Rules for each element of slice, array or each value of map
+func Each(args ...interface{}) validation.Dive {
+	return validation.Dive{Values: args}
+}
+
+// Rules for each key of map
+func EachKey(args ...interface{}) validation.Dive {
+	return validation.Dive{Keys: args}
+}
@@ -1,101 +1,168 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // Wrapper for pass custom validators in functional way
+// If Option is set Function is a condition what turns on the option when returns nil
 type Wrapper struct {
-	Function  Validator
-	Params    []interface{}
-	Reflected bool
+	Name            string
+	Function        Validator
+	ContextFunction ContextValidator // it is called instead of Function if set
+	Params          []interface{}
+	Reflected       bool
+	CrossField      bool
+	Option          Option
+
+	params []interface{} // precompiled params
 }
 
 // Represent struct attribute for validation
 type Field struct {
-	Name  string
-	Value reflect.Value
-	Rules string
+	Name     string
+	Value    reflect.Value
+	Rules    string
+	Embedded bool
 }
 
 var defaultTag = "valid"
 
+// Function what returns name of struct field for paths of errors and keys of Cast
+// Empty name means what Go name of field is used, "-" means what field is not named,
+// its errors are stored by Go name and it is not filled by Cast
+type NameFunc func(field reflect.StructField) string
+
+// Naming strategy of fields, Go names are used if it is nil
+var FieldName NameFunc
+
+// Struct what checks invariants of many fields
+// Method is called after validation of fields, its errors are merged by paths relative to the struct
+// Method must not validate the same struct by ValidateStruct, it causes infinite recursion
+type Validatable interface {
+	Validate() ErrorMap
+}
+
+// Struct what checks invariants of many fields with context of validation
+// It is used instead of Validatable if both are implemented
+type ContextValidatable interface {
+	ValidateCtx(ctx context.Context) ErrorMap
+}
+
+// Struct reached by pointer, embedded struct has the same address as its parent but another type
+type visit struct {
+	typeOf  reflect.Type
+	address uintptr
+}
+
+var (
+	validatableType        = reflect.TypeOf((*Validatable)(nil)).Elem()
+	contextValidatableType = reflect.TypeOf((*ContextValidatable)(nil)).Elem()
+)
+
+// If this mode is on configuration errors like unknown rules or wrong value types
+// are returned as *ConfigError within validation errors instead of panics
+var SafeMode = false
+
 // Validate structure
+// Nested structs and pointers to structs are validated recursively,
+// their errors are stored by dotted paths like "Customer.Email"
 func ValidateStruct(s interface{}, tags ...string) ErrorMap {
-	errs := ErrorMap{}
-	fields := InspectStruct(s, tags...)
+	return defaultEngine().ValidateStruct(s, tags...)
+}
 
-	for _, field := range fields {
-		fieldErrs := validate(s, field.Value, field.Rules)
-		if fieldErrs != nil {
-			errs[field.Name] = fieldErrs
-		}
-	}
+// Validate structure and store values changed by actions like "trim" to its fields
+// Struct must be passed by pointer
+func ValidateAndSanitize(s interface{}, tags ...string) ErrorMap {
+	return defaultEngine().ValidateAndSanitize(s, tags...)
+}
 
-	return errs
+// Perform actions like "trim" on fields of structure and store changed values without validation
+// Struct must be passed by pointer
+func SanitizeStruct(s interface{}, tags ...string) ErrorMap {
+	return defaultEngine().SanitizeStruct(s, tags...)
 }
 
 // Validate scalar value
+// Errors of elements validated by Dive rules are appended to the value's errors
 func ValidateValue(value interface{}, args ...interface{}) ErrorList {
-	return validate(value, value, args...)
+	return defaultEngine().ValidateValue(value, args...)
 }
 
-func InspectStruct(s interface{}, tags ...string) (res []Field) {
-	typeOf := reflect.TypeOf(s)
-	valueOf := reflect.ValueOf(s)
+// Validate structure with context
+// Context is passed to validators what use it, cancellation stops validation and its error is returned
+func ValidateStructCtx(ctx context.Context, s interface{}, tags ...string) (ErrorMap, error) {
+	return defaultEngine().ValidateStructCtx(ctx, s, tags...)
+}
 
-	if typeOf.Kind() != reflect.Struct {
-		panic(errorWrongType)
-	}
+// Validate scalar value with context
+func ValidateValueCtx(ctx context.Context, value interface{}, args ...interface{}) (ErrorList, error) {
+	return defaultEngine().ValidateValueCtx(ctx, value, args...)
+}
 
-	for i := 0; i < typeOf.NumField(); i++ {
-		f := Field{
-			Name:  typeOf.Field(i).Name,
-			Value: valueOf.Field(i),
-		}
+// Validate value of struct field
+// Struct is used by rules what depend on other fields like "eq_field" or "required_if"
+func ValidateField(s interface{}, value interface{}, args ...interface{}) ErrorList {
+	return defaultEngine().ValidateField(s, value, args...)
+}
 
-		if len(tags) > 0 {
-			for _, tag := range tags {
-				tagValue := typeOf.Field(i).Tag.Get(tag)
-				if len(tagValue) > 0 && len(f.Rules) > 0 {
-					f.Rules = f.Rules + "|" + tagValue
-				} else {
-					f.Rules = tagValue
-				}
-			}
-		} else {
-			f.Rules = typeOf.Field(i).Tag.Get(defaultTag)
-		}
+// Return struct fields with rules from specified tags
+// Fields of embedded structs are promoted to the struct level the way Go promotes them
+func InspectStruct(s interface{}, tags ...string) []Field {
+	return defaultEngine().InspectStruct(s, tags...)
+}
 
-		res = append(res, f)
+// Return naming strategy what takes names from tag like `json:"email,omitempty"`
+func TagName(tag string) NameFunc {
+	return func(field reflect.StructField) string {
+		name := field.Tag.Get(tag)
+		if i := strings.Index(name, ","); i >= 0 {
+			name = name[:i]
+		}
+		return name
 	}
-
-	return res
 }
 
 func By(function Validator, params ...interface{}) Wrapper {
 	return Wrapper{Function: function, Params: params}
 }
 
+func ByContext(function ContextValidator, params ...interface{}) Wrapper {
+	return Wrapper{Function: function.background(), ContextFunction: function, Params: params}
+}
+
 // Find and grouping rules by validators, options, actions
-func prepareRules(args ...interface{}) ([]Wrapper, OptionList, ActionMap) {
+func prepareRules(args ...interface{}) ([]Wrapper, OptionList, []Action) {
+	return defaultEngine().prepareRules(args...)
+}
+
+// Find and grouping rules by validators, options, actions of engine
+func (e *Engine) prepareRules(args ...interface{}) ([]Wrapper, OptionList, []Action) {
 	var wrappers []Wrapper
 	var options OptionList
-	var actions = make(ActionMap)
+	var actions []Action
+
+	prepareRule := func(rule Rule, wrp *[]Wrapper, options *OptionList, actions *[]Action) {
+		if validator, ok := e.contextValidator(rule.Name); ok {
+			*wrp = append(*wrp, Wrapper{Name: rule.Name, Function: validator.background(), ContextFunction: validator, Params: rule.Params, Reflected: rule.IsBuiltin()})
 
-	prepareRule := func(rule Rule, wrp *[]Wrapper, options *OptionList, actions ActionMap) {
-		if validator, ok := rule.Validator(); ok {
-			*wrp = append(*wrp, Wrapper{Function: validator, Params: rule.Params, Reflected: rule.IsBuiltin()})
+		} else if validator, ok := e.validator(rule.Name); ok {
+			*wrp = append(*wrp, Wrapper{Name: rule.Name, Function: validator, Params: rule.Params, Reflected: rule.IsBuiltin(), CrossField: rule.IsCrossField()})
 
-		} else if option, ok := rule.Option(); ok {
+		} else if option, ok := e.option(rule.Name); ok {
 			*options = append(*options, option)
 
-		} else if action, ok := rule.Action(); ok {
-			actions[actions.key()] = action
+		} else if action, ok := e.action(rule.Name); ok {
+			*actions = append(*actions, action)
+
+		} else if condition, ok := rule.Condition(); ok {
+			*wrp = append(*wrp, condition)
 
 		} else {
-			panic(fmt.Sprintf("Rule \"%s\" not found", rule.Name))
+			panic(&ConfigError{Rule: rule.Name, Err: ErrRuleNotFound})
 		}
 	}
 
@@ -105,12 +172,15 @@ func prepareRules(args ...interface{}) ([]Wrapper, OptionList, ActionMap) {
 		case string:
 			rules := Parse(arg.(string))
 			for _, rule := range rules {
-				prepareRule(rule, &wrappers, &options, actions)
+				prepareRule(rule, &wrappers, &options, &actions)
 			}
 
 		case Validator:
 			wrappers = append(wrappers, Wrapper{Function: arg.(Validator)})
 
+		case ContextValidator:
+			wrappers = append(wrappers, ByContext(arg.(ContextValidator)))
+
 		case Wrapper:
 			wrappers = append(wrappers, arg.(Wrapper))
 
@@ -118,57 +188,252 @@ func prepareRules(args ...interface{}) ([]Wrapper, OptionList, ActionMap) {
 			options = append(options, arg.(Option))
 
 		case Action:
-			actions.Add(actions.key(), arg.(Action))
+			actions = append(actions, arg.(Action))
 
 		case Rule:
-			prepareRule(arg.(Rule), &wrappers, &options, actions)
+			prepareRule(arg.(Rule), &wrappers, &options, &actions)
 
 		case func(interface{}, OptionList, ...interface{}) error:
 			function := arg.(func(interface{}, OptionList, ...interface{}) error)
 			wrappers = append(wrappers, Wrapper{Function: function})
 
+		case func(context.Context, interface{}, OptionList, ...interface{}) error:
+			function := arg.(func(context.Context, interface{}, OptionList, ...interface{}) error)
+			wrappers = append(wrappers, ByContext(function))
+
 		case func(interface{}) interface{}:
 			action := arg.(func(interface{}) interface{})
-			actions[actions.key()] = action
+			actions = append(actions, action)
 
 		default:
-			fmt.Printf("###### %T", arg)
-			panic(errorWrongType)
+			panic(&ConfigError{Err: fmt.Errorf("%w: argument of type %T", ErrWrongType, arg)})
 		}
 	}
 
 	return wrappers, options, actions
 }
 
-func validate(fullValue interface{}, value interface{}, args ...interface{}) ErrorList {
-	var errs ErrorList
-	wrappers, options, actions := prepareRules(args...)
+// Validate struct fields and descend into nested structs
+func (e *Engine) validateStruct(errs ErrorMap, path string, s interface{}, tags []string) {
+	valueOf := valueOf(s)
+	if e.writeBack && len(path) == 0 && valueOf.Kind() != reflect.Ptr {
+		panic(errorWrongType)
+	}
 
-	for _, action := range actions {
-		value = action(value)
+	valueOf = reflect.Indirect(valueOf)
+	if valueOf.Kind() != reflect.Struct {
+		panic(errorWrongType)
+	}
+
+	if e.visited != nil && valueOf.CanAddr() {
+		key := visit{typeOf: valueOf.Type(), address: valueOf.UnsafeAddr()}
+		if e.visited[key] {
+			return
+		}
+		e.visited[key] = true
+	}
+
+	// nested struct is walked by reflected value to keep it addressable
+	if nested, ok := s.(reflect.Value); ok {
+		s = nested.Interface()
+	}
+
+	for _, field := range e.structPlan(valueOf.Type(), e.structTags(tags)) {
+		value, err := valueOf.FieldByIndexErr(field.index)
+		if err != nil {
+			continue // field of nil embedded pointer
+		}
+
+		name, ok := e.externalName(field.field)
+		if !ok {
+			name = field.name // field is not named by strategy, but it is validated
+		}
+		fieldPath := joinPath(path, name)
+
+		// fields of embedded struct are already promoted
+		if field.embedded {
+			e.validateRules(errs, fieldPath, s, value, field.plan)
+			continue
+		}
+
+		e.validateField(errs, fieldPath, s, value, tags, field.plan)
 	}
 
+	e.validateSelf(errs, path, valueOf)
+}
+
+// Merge errors of struct what validates itself after its fields
+// Keys of errors are relative to struct, empty key is the struct itself
+func (e *Engine) validateSelf(errs ErrorMap, path string, valueOf reflect.Value) {
+	if e.sanitizeOnly || e.canceled() {
+		return
+	}
+
+	pointerType := reflect.PtrTo(valueOf.Type())
+	if !pointerType.Implements(validatableType) && !pointerType.Implements(contextValidatableType) || !valueOf.CanInterface() {
+		return
+	}
+
+	// methods of pointer receiver are called on copy of not addressable struct
+	if !valueOf.CanAddr() {
+		addressable := reflect.New(valueOf.Type()).Elem()
+		addressable.Set(valueOf)
+		valueOf = addressable
+	}
+	valueOf = valueOf.Addr()
+
+	var selfErrs ErrorMap
+	switch s := valueOf.Interface().(type) {
+	case ContextValidatable:
+		selfErrs = s.ValidateCtx(e.context())
+	case Validatable:
+		selfErrs = s.Validate()
+	default:
+		return
+	}
+
+	for key, list := range selfErrs {
+		if len(list) == 0 {
+			continue
+		}
+
+		keyPath := path
+		if len(key) > 0 {
+			keyPath = joinPath(path, key)
+		}
+		for _, err := range list {
+			if fieldErr, ok := err.(*FieldError); ok && len(fieldErr.Field) == 0 {
+				fieldErr.Field = keyPath
+			}
+		}
+		errs[keyPath] = append(errs[keyPath], list...)
+	}
+}
+
+// Validate value, nested struct or elements of collection
+func (e *Engine) validateField(errs ErrorMap, path string, parent interface{}, value reflect.Value, tags []string, p *plan) {
+	if !e.validateRules(errs, path, parent, value, p) {
+		return
+	}
+
+	if nested, ok := nestedStruct(value); ok {
+		e.validateStruct(errs, path, nested, tags)
+		return
+	}
+
+	e.validateElements(errs, path, parent, value, tags, p)
+}
+
+// Validate value by own rules of plan
+// It returns false if value is ignored
+func (e *Engine) validateRules(errs ErrorMap, path string, parent interface{}, value interface{}, p *plan) (ok bool) {
+	if e.SafeMode {
+		defer func() {
+			if r := recover(); r != nil {
+				err := configError("", r)
+				err.Field = path
+				errs[path] = append(errs[path], err)
+				ok = false
+			}
+		}()
+	}
+
+	if e.canceled() {
+		return false
+	}
+
+	if p.failure != nil {
+		panic(p.failure)
+	}
+
+	value = e.runActions(value, p.actions)
+	if e.sanitizeOnly {
+		return !p.options.Has(Ignore)
+	}
+
+	valueErrs := e.check(parent, value, p.wrappers, p.options)
+	for _, err := range valueErrs {
+		switch err := err.(type) {
+		case *FieldError:
+			err.Field = path
+		case *ConfigError:
+			err.Field = path
+		}
+	}
+	if len(valueErrs) > 0 {
+		errs[path] = append(errs[path], valueErrs...)
+	}
+
+	return !p.options.Has(Ignore)
+}
+
+// Run prepared rules for value
+func (e *Engine) check(fullValue interface{}, value interface{}, wrappers []Wrapper, options OptionList) ErrorList {
+	var errs ErrorList
+
 	reflectedValue := valueOf(value)
+	if reflectedValue.Kind() == reflect.Interface && !reflectedValue.IsNil() {
+		reflectedValue = reflectedValue.Elem() // elements of []interface{} like decoded JSON
+	}
 
 	if options.Has(Ignore) {
 		return ErrorList{}
 	}
 
-	if options.Has(Required) == false {
-		if empty(reflectedValue, OptionList{}) == nil {
-			return ErrorList{}
+	// conditional options are turned on by other fields
+	var conditions map[Option]Wrapper
+	for _, wrapper := range wrappers {
+		if len(wrapper.Option) == 0 {
+			continue
+		}
+		if e.canceled() {
+			return errs
+		}
+
+		err := e.callValidator(wrapper, fullValue, reflectedValue, options)
+		if configErr, ok := err.(*ConfigError); ok {
+			return ErrorList{configErr}
+		} else if err == nil {
+			if conditions == nil {
+				conditions = make(map[Option]Wrapper)
+			}
+			conditions[wrapper.Option] = wrapper
+			options = append(options[:len(options):len(options)], wrapper.Option)
+		}
+	}
+
+	// booleans of documents are present values, only missing values and nulls are empty
+	if empty(reflectedValue, OptionList{}) == nil && !(e.dynamic && reflectedValue.Kind() == reflect.Bool) {
+		if options.Has(Required) {
+			return ErrorList{e.fieldError(optionError(Required, conditions), Wrapper{}, reflectedValue)}
 		}
+		return ErrorList{}
+	}
+
+	if options.Has(Excluded) {
+		return ErrorList{e.fieldError(optionError(Excluded, conditions), Wrapper{}, reflectedValue)}
+	}
+
+	if options.Has(Numeric) && reflectedValue.Kind() == reflect.String && parseDecimal(reflectedValue.String()) == nil {
+		return ErrorList{e.fieldError(errorMessage(string(Numeric)), Wrapper{}, reflectedValue)}
 	}
 
 	for _, wrapper := range wrappers {
-		var err error
-		if wrapper.Reflected {
-			err = wrapper.Function(reflectedValue, options, wrapper.Params)
-		} else {
-			err = wrapper.Function(fullValue, options, wrapper.Params)
+		if len(wrapper.Option) > 0 {
+			continue
 		}
-		if err != nil {
-			errs = append(errs, err)
+		if e.canceled() {
+			return errs
+		}
+
+		err := e.callValidator(wrapper, fullValue, reflectedValue, options)
+		if err != nil && e.canceled() {
+			return errs // error of validator is caused by cancellation
+		}
+		if configErr, ok := err.(*ConfigError); ok {
+			errs = append(errs, configErr)
+		} else if err != nil {
+			errs = append(errs, e.fieldError(err, wrapper, reflectedValue))
 			if options.Has(Lazy) {
 				return errs
 			}
@@ -177,3 +442,87 @@ func validate(fullValue interface{}, value interface{}, args ...interface{}) Err
 
 	return errs
 }
+
+// Run actions in declaration order
+// In write back mode changed value of field is stored to struct
+func (e *Engine) runActions(value interface{}, actions []Action) interface{} {
+	if len(actions) == 0 {
+		return value
+	}
+
+	field, isField := value.(reflect.Value)
+	target := field // pointers are followed, interface is kept to store value of another type
+	if isField {
+		for target.Kind() == reflect.Ptr && !target.IsNil() {
+			target = target.Elem()
+		}
+		field = indirect(field)
+		if !field.IsValid() || !field.CanInterface() {
+			return value
+		}
+		value = field.Interface()
+	}
+
+	for _, action := range actions {
+		value = action(value)
+	}
+
+	if isField && e.writeBack && target.CanSet() {
+		setValue(target, valueOf(value))
+	}
+
+	return value
+}
+
+// Call validator of wrapper
+// In safe mode panic of validator is converted to configuration error
+func (e *Engine) callValidator(wrapper Wrapper, fullValue interface{}, value reflect.Value, options OptionList) (err error) {
+	if e.SafeMode {
+		defer func() {
+			if r := recover(); r != nil {
+				err = configError(wrapper.Name, r)
+			}
+		}()
+	}
+	if check, ok := e.documentRule(wrapper.Name, value); ok {
+		return check(value)
+	}
+	if e.dynamic {
+		defer func() {
+			if r := recover(); r != nil {
+				if r != errorWrongType {
+					panic(r)
+				}
+				err = wrongType(wrapper.Name)
+			}
+		}()
+	}
+
+	params := wrapper.Params
+	if wrapper.params != nil {
+		params = wrapper.params
+	}
+
+	if wrapper.ContextFunction != nil {
+		ctx := e.context()
+		wrapper.Function = func(value interface{}, options OptionList, params ...interface{}) error {
+			return wrapper.ContextFunction(ctx, value, options, params...)
+		}
+	}
+
+	if wrapper.CrossField {
+		return wrapper.Function(crossValue{Value: value, Struct: valueOf(fullValue)}, options, params...)
+	}
+	if wrapper.Reflected {
+		return wrapper.Function(value, options, params...)
+	}
+	return wrapper.Function(fullValue, options, params...)
+}
+
+// Return error of option turned on by condition or by itself
+func optionError(option Option, conditions map[Option]Wrapper) error {
+	if wrapper, ok := conditions[option]; ok {
+		return errorMessage(wrapper.Name, wrapper.Params...)
+	}
+	return errorMessage(string(option))
+}
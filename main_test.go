@@ -1,7 +1,9 @@
 package validation
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestPrepareRules(t *testing.T) {
@@ -24,3 +26,216 @@ func TestPrepareRules(t *testing.T) {
 		t.Error("Error finding actions.")
 	}
 }
+
+type testCustomer struct {
+	Name  string `valid:"required|min:2"`
+	Email string `valid:"required|email"`
+}
+
+type testAddress struct {
+	City string `valid:"required"`
+}
+
+type testBase struct {
+	ID int `valid:"required"`
+}
+
+type testOrder struct {
+	testBase
+	Customer testCustomer
+	Address  *testAddress
+	Comment  string
+	Created  time.Time
+}
+
+func TestValidateStruct_Nested(t *testing.T) {
+	order := testOrder{
+		Customer: testCustomer{Name: "J", Email: "fake"},
+		Address:  &testAddress{},
+	}
+
+	errs := ValidateStruct(order)
+
+	for _, name := range []string{"ID", "Customer.Name", "Customer.Email", "Address.City"} {
+		if errs[name].Empty() {
+			t.Errorf("Expected errors for %s.", name)
+		}
+	}
+	if len(errs) != 4 {
+		t.Errorf("Expected 4 fields with errors, got %s.", errs.JSON())
+	}
+
+	valid := testOrder{
+		testBase: testBase{ID: 1},
+		Customer: testCustomer{Name: "John", Email: "john@example.com"},
+	}
+	if errs := ValidateStruct(&valid); !errs.Empty() {
+		t.Errorf("Unexpected errors %s.", errs.JSON())
+	}
+}
+
+type testNode struct {
+	Name     string `valid:"required"`
+	Next     *testNode
+	Children []*testNode
+}
+
+func TestValidateStruct_Cycle(t *testing.T) {
+	node := &testNode{}
+	node.Next = node
+	node.Children = []*testNode{node, {Next: node}}
+
+	errs := ValidateStruct(node)
+	if errs.JSON() != `{"Children[1].Name":["is required"],"Name":["is required"]}` {
+		t.Errorf("Wrong errors of cycle: %s", errs.JSON())
+	}
+
+	if list := ValidateValue(node.Children, "dive"); len(list) != 2 {
+		t.Errorf("Wrong errors of cycle in elements: %v", list)
+	}
+}
+
+func TestInspectStruct_Embedded(t *testing.T) {
+	fields := InspectStruct(testOrder{})
+
+	names := make(map[string]bool)
+	for _, f := range fields {
+		names[f.Name] = true
+	}
+
+	if !names["ID"] || !names["testBase"] || !names["Customer"] {
+		t.Error("Error promoting embedded fields.")
+	}
+}
+
+type testTag struct {
+	Name string `valid:"required|alpha"`
+}
+
+type testPost struct {
+	Emails []string          `valid:"max:3|dive|email"`
+	Tags   map[string]string `valid:"dive|keys|alpha|endkeys|max:5"`
+	Items  []testTag
+	Matrix [][]string `valid:"dive|dive|in:x,y"`
+}
+
+func TestValidateStruct_Dive(t *testing.T) {
+	post := testPost{
+		Emails: []string{"a@example.com", "b@example.com", "fake"},
+		Tags:   map[string]string{"color": "greenish", "size1": "xl"},
+		Items:  []testTag{{Name: "go"}, {}},
+		Matrix: [][]string{{"x"}, {"y", "z"}},
+	}
+
+	errs := ValidateStruct(post)
+
+	for _, name := range []string{"Emails[2]", "Tags[color]", "Tags[size1]", "Items[1].Name", "Matrix[1][1]"} {
+		if errs[name].Empty() {
+			t.Errorf("Expected errors for %s.", name)
+		}
+	}
+	if len(errs) != 5 {
+		t.Errorf("Expected 5 fields with errors, got %s.", errs.JSON())
+	}
+}
+
+func TestValidateValue_Each(t *testing.T) {
+	if errs := ValidateValue([]string{"a@example.com", "fake", ""}, Each(Email())); len(errs) != 1 {
+		t.Error("Error validating elements.")
+	}
+	if errs := ValidateValue(map[string]int{"a": 1, "b2": 2}, EachKey(Alpha()), Each(Max(1))); len(errs) != 2 {
+		t.Error("Error validating map keys and values.")
+	}
+}
+
+func TestValidateStruct_SafeMode(t *testing.T) {
+	SafeMode = true
+	defer func() { SafeMode = false }()
+
+	s := struct {
+		Age   int    `valid:"email"`
+		Name  string `valid:"fake|min:2"`
+		Regex string `valid:"regex:[a-z"`
+		Email string `valid:"email"`
+	}{Age: 1, Name: "x", Regex: "x", Email: "fake"}
+
+	errs := ValidateStruct(s)
+
+	configErrs := errs.ConfigErrors()
+	if len(configErrs) < 3 || len(errs["Regex"].ConfigErrors()) == 0 {
+		t.Fatalf("Expected config errors, got %v.", configErrs)
+	}
+	if configErrs[0].Field != "Age" || configErrs[0].Rule != "email" || !errors.Is(configErrs[0], ErrWrongType) {
+		t.Errorf("Wrong config error %v.", configErrs[0])
+	}
+	if configErrs[1].Field != "Name" || configErrs[1].Rule != "fake" || !errors.Is(configErrs[1], ErrRuleNotFound) {
+		t.Errorf("Wrong config error %v.", configErrs[1])
+	}
+	if len(errs["Email"].ConfigErrors()) != 0 || errs["Email"].Empty() {
+		t.Error("Error separating config errors from validation errors.")
+	}
+
+	if errs := ValidateStruct(1); len(errs.ConfigErrors()) != 1 {
+		t.Error("Error validating not a struct in safe mode.")
+	}
+}
+
+type testAccount struct {
+	Email    string `json:"email,omitempty" valid:"required|email"`
+	Password string `json:"-" valid:"required"`
+	Nick     string `json:",omitempty" valid:"required"`
+	Profile  struct {
+		Phones []string `json:"phones" valid:"dive|int"`
+	} `json:"profile"`
+}
+
+func TestValidateStruct_FieldName(t *testing.T) {
+	e := New()
+	e.FieldName = TagName("json")
+
+	var a testAccount
+	a.Profile.Phones = []string{"1", "x"}
+
+	errs := e.ValidateStruct(a)
+	for _, path := range []string{"email", "Password", "Nick", "profile.phones[1]"} {
+		if len(errs[path]) != 1 || errs[path][0].(*FieldError).Field != path {
+			t.Errorf("Error naming %s: %v", path, errs)
+		}
+	}
+	if len(errs) != 4 {
+		t.Errorf("Wrong errors: %v", errs)
+	}
+
+	data := map[string]interface{}{"email": "a@b.c", "Password": "secret", "Nick": "n"}
+	if errs := e.Cast(data, &a); !errs.Empty() || a.Email != "a@b.c" || a.Password != "" || a.Nick != "n" {
+		t.Errorf("Error casting by names: %+v", a)
+	}
+}
+
+func TestValidateValue_Required(t *testing.T) {
+	errs := ValidateValue("", "required|email|min:3")
+
+	var fieldErr *FieldError
+	if len(errs) != 1 || !errors.As(errs[0], &fieldErr) || fieldErr.Rule != "required" || fieldErr.Message != "is required" {
+		t.Errorf("Wrong errors of empty required value: %v", errs)
+	}
+
+	if errs := ValidateValue("", "email|min:3"); len(errs) != 0 {
+		t.Errorf("Empty value without required is validated: %v", errs)
+	}
+}
+
+func TestValidateValue_Params(t *testing.T) {
+	var got []interface{}
+	v := func(v interface{}, options OptionList, params ...interface{}) error {
+		got = params
+		return nil
+	}
+
+	Validators.Add("test_params", v)
+	ValidateValue("x", "test_params:a,b")
+
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Params are not passed one by one: %#v", got)
+	}
+}
@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Segment of schema path what means each element of array or object
+const eachSegment = "*"
+
+// Rules of map path
+type pathRules struct {
+	path  string
+	rules string
+}
+
+// Validate map like decoded JSON by schema of rules
+// Schema is a map of paths to rules like map[string]string{"customer.email": "required|email"}
+// or a nested map like map[string]interface{}{"customer": map[string]string{"email": "required|email"}},
+// rules of nested object itself are stored by empty key. Segment "*" means each element of array
+// or object like "items.*.qty". Errors are stored by paths like "items[0].qty"
+func ValidateMap(data interface{}, schema interface{}) ErrorMap {
+	return defaultEngine().ValidateMap(data, schema)
+}
+
+// Validate map like decoded JSON by schema of rules
+// Values of types what rules do not accept fail with error like "must be a value of type string"
+func (e *Engine) ValidateMap(data interface{}, schema interface{}) ErrorMap {
+	validator := *e
+	validator.dynamic = true
+	validator.visited = make(map[visit]bool)
+	return validator.validateMap(data, schema)
+}
+
+// Validate map by schema of rules
+func (e *Engine) validateMap(data interface{}, schema interface{}) (errs ErrorMap) {
+	errs = ErrorMap{}
+	if e.SafeMode {
+		defer func() {
+			if r := recover(); r != nil {
+				errs[""] = append(errs[""], configError("", r))
+			}
+		}()
+	}
+
+	valueOf := indirect(valueOf(data))
+	if valueOf.Kind() != reflect.Map || valueOf.Type().Key().Kind() != reflect.String {
+		panic(errorWrongType)
+	}
+
+	for _, item := range flattenSchema("", schema, nil) {
+		var segments []string
+		if len(item.path) > 0 {
+			segments = strings.Split(item.path, ".")
+		}
+		e.validatePath(errs, "", valueOf, valueOf, segments, e.compileRules(item.rules))
+	}
+	return errs
+}
+
+// Convert schema to list of paths and rules sorted by path
+func flattenSchema(path string, schema interface{}, res []pathRules) []pathRules {
+	if rules, ok := schema.(string); ok {
+		return append(res, pathRules{path: path, rules: rules})
+	}
+
+	valueOf := indirect(valueOf(schema))
+	if valueOf.Kind() != reflect.Map || valueOf.Type().Key().Kind() != reflect.String {
+		panic(&ConfigError{Field: path, Err: fmt.Errorf("schema must be rules or map, %T given", schema)})
+	}
+
+	keys := valueOf.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, key := range keys {
+		keyPath := path
+		if len(key.String()) > 0 {
+			keyPath = joinPath(path, key.String())
+		}
+		res = flattenSchema(keyPath, valueOf.MapIndex(key).Interface(), res)
+	}
+	return res
+}
+
+// Validate values of map by path segments
+// Missing values are validated as nil, so "required" fails for them
+func (e *Engine) validatePath(errs ErrorMap, path string, parent reflect.Value, value reflect.Value, segments []string, p *plan) {
+	if len(segments) == 0 {
+		var raw interface{}
+		if value.IsValid() {
+			raw = value.Interface()
+		}
+		if e.validateRules(errs, path, parent.Interface(), raw, p) {
+			e.validateElements(errs, path, parent.Interface(), value, nil, p)
+		}
+		return
+	}
+
+	node := indirect(value)
+	segment, rest := segments[0], segments[1:]
+
+	if segment == eachSegment {
+		switch node.Kind() {
+		case reflect.Invalid:
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < node.Len(); i++ {
+				e.validatePath(errs, fmt.Sprintf("%s[%d]", path, i), node, node.Index(i), rest, p)
+			}
+		case reflect.Map:
+			keys := node.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+			})
+			for _, key := range keys {
+				e.validatePath(errs, fmt.Sprintf("%s[%v]", path, key), node, node.MapIndex(key), rest, p)
+			}
+		default:
+			e.typeFailed(errs, path, "array")
+		}
+		return
+	}
+
+	var child reflect.Value
+	switch {
+	case node.Kind() == reflect.Invalid:
+	case node.Kind() == reflect.Map && node.Type().Key().Kind() == reflect.String:
+		child = node.MapIndex(reflect.ValueOf(segment).Convert(node.Type().Key()))
+	default:
+		e.typeFailed(errs, path, "object")
+		return
+	}
+
+	if !node.IsValid() {
+		node = reflect.ValueOf(map[string]interface{}{}) // fields of missing object are missing too
+	}
+	e.validatePath(errs, joinPath(path, segment), node, child, rest, p)
+}
+
+// JSON types of values of build-in rules what do not accept strings only
+var ruleTypes = map[string]string{
+	"min_value": "number",
+	"max_value": "number",
+	"between":   "number",
+	"int":       "string or number",
+	"float":     "string or number",
+	"accepted":  "string or boolean",
+	"in":        "string or number",
+	"not_in":    "string or number",
+	"min":       "number, string, array or object",
+	"max":       "number, string, array or object",
+	"gt":        "number, string, array or object",
+	"lt":        "number, string, array or object",
+	"len":       "string, array or object",
+}
+
+// Checks of JSON numbers by rules what accept numeric strings only in structs
+// Documents decode numbers to float64, so integers are checked by fractional part
+var numberRules = map[string]func(value reflect.Value) error{
+	"int": func(value reflect.Value) error {
+		if value.CanFloat() && value.Float() != math.Trunc(value.Float()) {
+			return errorMessage("int")
+		}
+		return nil
+	},
+	"float": func(value reflect.Value) error {
+		return nil
+	},
+}
+
+// Checks of JSON booleans by rules what accept strings only in structs
+var boolRules = map[string]func(value reflect.Value) error{
+	"accepted": func(value reflect.Value) error {
+		if !value.Bool() {
+			return errorMessage("accepted")
+		}
+		return nil
+	},
+}
+
+// Return check of value of document by rule if rule accepts JSON type of value
+func (e *Engine) documentRule(rule string, value reflect.Value) (func(value reflect.Value) error, bool) {
+	if !e.dynamic {
+		return nil, false
+	}
+
+	var check func(value reflect.Value) error
+	switch {
+	case isNumber(value):
+		check = numberRules[rule]
+	case value.Kind() == reflect.Bool:
+		check = boolRules[rule]
+	}
+	return check, check != nil
+}
+
+// Return error of value type what rule does not accept
+func wrongType(rule string) error {
+	typeName, ok := ruleTypes[rule]
+	if !ok {
+		typeName = "string"
+	}
+	return errorMessage("type", typeName)
+}
+
+// Store error of value type by path, it is stored once
+func (e *Engine) typeFailed(errs ErrorMap, path string, typeName string) {
+	for _, err := range errs[path] {
+		if fieldErr, ok := err.(*FieldError); ok && fieldErr.Rule == "type" {
+			return
+		}
+	}
+
+	err := e.fieldError(errorMessage("type", typeName), Wrapper{}, reflect.Value{})
+	err.Field = path
+	errs[path] = append(errs[path], err)
+}
@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateMap(t *testing.T) {
+	var data map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"event": "order.paid",
+		"customer": {"email": "bob", "phone": ""},
+		"items": [{"sku": "A1", "qty": 2}, {"sku": "", "qty": 0}],
+		"tags": ["a", "bb"],
+		"meta": {"x": 1, "y": "2"},
+		"total": "12.50"
+	}`), &data)
+
+	schema := map[string]interface{}{
+		"event":    "required|in:order.paid,order.refunded",
+		"customer": map[string]interface{}{"": "required", "email": "required|email", "phone": "required_without:email"},
+		"items":    "required|min:1",
+		"items.*":  map[string]string{"sku": "required", "qty": "required|gt:0"},
+		"tags":     "max:3|dive|min:2",
+		"meta.*":   "required",
+		"total":    "numeric|min_value:10",
+		"shipping": map[string]string{"address": "required", "city": "required_with:address"},
+		"refund":   "excluded_unless:event,order.refunded",
+	}
+
+	r := `{"customer.email":["must be a valid email address"],"items[1].qty":["is required"],"items[1].sku":["is required"],` +
+		`"shipping.address":["is required"],"tags[0]":["must be greater or equal of 2"]}`
+
+	if errs := ValidateMap(data, schema); errs.JSON() != r {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+
+	if errs := ValidateMap(data, map[string]string{"": "has_keys:event,id"}); len(errs[""]) != 1 {
+		t.Errorf("Wrong errors of root: %s", errs.JSON())
+	}
+
+	account := map[string]interface{}{"password": "secret", "confirm": "secret1", "email": ""}
+	r = `{"confirm":["must be equal to password"],"phone":["is required when email is not present"]}`
+	if errs := ValidateMap(account, map[string]string{"confirm": "eq_field:password", "phone": "required_without:email"}); errs.JSON() != r {
+		t.Errorf("Wrong errors of cross field rules: %s", errs.JSON())
+	}
+}
+
+func TestValidateMap_Types(t *testing.T) {
+	data := map[string]interface{}{
+		"customer": "bob",
+		"items":    map[string]interface{}{"a": map[string]interface{}{"qty": 0.0}},
+		"tags":     5,
+	}
+
+	schema := map[string]string{
+		"customer.email": "required",
+		"customer.phone": "required",
+		"items.*.qty":    "required",
+		"tags.*":         "min:2",
+	}
+
+	r := `{"customer":["must be a value of type object"],"items[a].qty":["is required"],"tags":["must be a value of type array"]}`
+	if errs := ValidateMap(data, schema); errs.JSON() != r {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+}
+
+func TestValidateMap_WrongTypes(t *testing.T) {
+	var data map[string]interface{}
+	json.Unmarshal([]byte(`{"email": 5, "age": 30, "tags": [1, "b"], "total": [1], "name": "Bob", "bonus": {"a": 1}}`), &data)
+
+	schema := map[string]string{
+		"email": "required|email",
+		"age":   "int",
+		"tags":  "dive|alpha",
+		"total": "min_value:1",
+		"name":  "email",
+		"bonus": "in:1,2",
+	}
+
+	r := `{"bonus":["must be a value of type string or number"],"email":["must be a value of type string"],"name":["must be a valid email address"],` +
+		`"tags[0]":["must be a value of type string"],"total":["must be a value of type number"]}`
+	if errs := ValidateMap(data, schema); errs.JSON() != r {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+
+	e := New()
+	e.SafeMode = true
+	if errs := e.ValidateMap(data, schema); len(errs.ConfigErrors()) != 0 || len(errs) != 5 {
+		t.Errorf("Wrong errors in safe mode: %v", errs)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Wrong type is reported by ValidateStruct.")
+		}
+	}()
+	ValidateValue(5, "email")
+}
+
+func TestValidateMap_Numbers(t *testing.T) {
+	var data map[string]interface{}
+	json.Unmarshal([]byte(`{"age": 30, "ratio": 0.5, "count": 2.5, "code": "7", "name": "Bob", "tags": ["a"], "flag": [false], "meta": {"a": 1}, "terms": false, "news": true}`), &data)
+
+	schema := map[string]string{
+		"age":     "int|min:18",
+		"ratio":   "float|lt:1",
+		"count":   "int",
+		"code":    "int|len:1",
+		"name":    "min:2|max:10",
+		"tags":    "max:2",
+		"flag.*":  "min:1",
+		"meta":    "len:1|gt:0",
+		"flag":    "len:2",
+		"tags.*":  "int",
+		"missing": "int",
+		"terms":   "required|accepted",
+		"news":    "accepted",
+	}
+
+	r := `{"count":["must be an integer number"],"flag":["must have length 2"],"flag[0]":["must be a value of type number, string, array or object"],` +
+		`"tags[0]":["must be an integer number"],"terms":["must be accepted"]}`
+	if errs := ValidateMap(data, schema); errs.JSON() != r {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+}
+
+func TestValidateMap_Config(t *testing.T) {
+	e := New()
+	e.SafeMode = true
+
+	if errs := e.ValidateMap(map[string]interface{}{}, map[string]interface{}{"a": 1}); len(errs.ConfigErrors()) != 1 {
+		t.Errorf("Wrong config errors: %v", errs)
+	}
+	if errs := e.ValidateMap(map[string]interface{}{"a": "x"}, map[string]string{"a": "fake_rule"}); len(errs.ConfigErrors()) != 1 {
+		t.Errorf("Wrong config errors: %v", errs)
+	}
+}
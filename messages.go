@@ -1,28 +1,283 @@
 package validation
 
+import "strings"
+
 // Map of validation error messages
-type messages map[string]string
+// It is safe for concurrent use
+type MessageMap struct {
+	registry
+}
+
+// Map of validation error messages by locale
+// It is safe for concurrent use
+type CatalogMap struct {
+	registry
+}
+
+// Default locale of validation error messages
+var Locale = "en"
+
+// Locale what is used if message not found in chain of requested locale
+const fallbackLocale = "en"
+
+// Validation error messages in english
+var Messages = englishMessages()
+
+// Return build-in messages in english
+func englishMessages() *MessageMap {
+	return newMessages(map[string]string{
+		"required":       "is required",
+		"empty":          "must be empty",
+		"email":          "must be a valid email address",
+		"url":            "must be a valid url",
+		"accepted":       "must be accepted",
+		"alpha":          "must contain only english letters",
+		"alpha_numeric":  "must contain only english letters and digits",
+		"alpha_under":    "must contain only english letters and underscores",
+		"alpha_dash":     "must contain only english letters and dashes",
+		"ascii":          "must contain only ASCII characters",
+		"int":            "must be an integer number",
+		"float":          "must be a float number",
+		"json":           "must be a valid JSON",
+		"ip":             "must be a valid IP address",
+		"ipv4":           "must be a valid IPv4 address",
+		"ipv6":           "must be a valid IPv6 address",
+		"time":           "must be a valid time in 15:04:05 format",
+		"upper_case":     "must be in upper case",
+		"lower_case":     "must be in lower case",
+		"country_code2":  "must be a valid country code in AA format",
+		"country_code3":  "must be a valid country code in AAA format",
+		"currency_code":  "must be a valid currency code",
+		"language_code2": "must be a valid language code in aa format",
+		"language_code3": "must be a valid language code in aaa format",
+		"credit_card":    "must be a valid credit card number",
+		"password":       "must contains at least english letters in both cases, numbers and have minimum length 8",
+		"min":            "must be greater or equal of {0}",
+		"max":            "must be lower or equal of {0}",
+		"min_value":      "must be greater or equal of {0}",
+		"max_value":      "must be lower or equal of {0}",
+		"between":        "must be between {0} and {1}",
+		"numeric":        "must be a number",
+		"len":            "must have length {0}",
+		"in":             "must be in {*}",
+		"not_in":         "must not be in {*}",
+		"date":           "must be a valid date in {0} format",
+		"regex":          "must match the pattern {0}",
+		"contains":       "must contain {0}",
+		"gt":             "must be greater than {0}",
+		"lt":             "must be lower than {0}",
+		"date_gte":       "must be a date greater or equal of {1}",
+		"date_lte":       "must be a date lower or equal of {1}",
+		"date_gt":        "must be a date greater than {1}",
+		"date_lt":        "must be a date lower than {1}",
+		"has_prefix":     "must begin with {0}",
+		"has_suffix":     "must end with {0}",
+		"has_keys":       "must have keys {*}",
+		"has_only_keys":  "must have only keys {*}",
+		"file_exists":    "must be an existing file",
+		"eq_field":       "must be equal to {0}",
+		"ne_field":       "must not be equal to {0}",
+		"gt_field":       "must be greater than {0}",
+		"gte_field":      "must be greater or equal of {0}",
+		"lt_field":       "must be lower than {0}",
+		"lte_field":      "must be lower or equal of {0}",
+		"bool":           "must be a boolean",
+		"type":           "must be a value of type {0}",
+
+		"excluded":             "must be empty",
+		"required_if":          "is required when {0} is {1}",
+		"required_unless":      "is required unless {0} is {1}",
+		"required_with":        "is required when {*} is present",
+		"required_with_all":    "is required when {*} are present",
+		"required_without":     "is required when {*} is not present",
+		"required_without_all": "is required when none of {*} are present",
+		"excluded_if":          "must be empty when {0} is {1}",
+		"excluded_unless":      "must be empty unless {0} is {1}",
+		"excluded_with":        "must be empty when {*} is present",
+		"excluded_without":     "must be empty when {*} is not present",
+	})
+}
+
+// Return build-in messages in russian
+func russianMessages() *MessageMap {
+	return newMessages(map[string]string{
+		"required":       "обязательно для заполнения",
+		"empty":          "должно быть пустым",
+		"email":          "должно быть корректным адресом электронной почты",
+		"url":            "должно быть корректным url",
+		"accepted":       "должно быть принято",
+		"alpha":          "должно содержать только английские буквы",
+		"alpha_numeric":  "должно содержать только английские буквы и цифры",
+		"alpha_under":    "должно содержать только английские буквы и подчеркивания",
+		"alpha_dash":     "должно содержать только английские буквы и дефисы",
+		"ascii":          "должно содержать только ASCII символы",
+		"int":            "должно быть целым числом",
+		"float":          "должно быть дробным числом",
+		"json":           "должно быть корректным JSON",
+		"ip":             "должно быть корректным IP адресом",
+		"ipv4":           "должно быть корректным IPv4 адресом",
+		"ipv6":           "должно быть корректным IPv6 адресом",
+		"time":           "должно быть корректным временем в формате 15:04:05",
+		"upper_case":     "должно быть в верхнем регистре",
+		"lower_case":     "должно быть в нижнем регистре",
+		"country_code2":  "должно быть корректным кодом страны в формате AA",
+		"country_code3":  "должно быть корректным кодом страны в формате AAA",
+		"currency_code":  "должно быть корректным кодом валюты",
+		"language_code2": "должно быть корректным кодом языка в формате aa",
+		"language_code3": "должно быть корректным кодом языка в формате aaa",
+		"credit_card":    "должно быть корректным номером кредитной карты",
+		"password":       "должно содержать английские буквы в обоих регистрах, цифры и иметь длину не менее 8",
+		"min":            "должно быть больше или равно {0}",
+		"max":            "должно быть меньше или равно {0}",
+		"min_value":      "должно быть больше или равно {0}",
+		"max_value":      "должно быть меньше или равно {0}",
+		"between":        "должно быть между {0} и {1}",
+		"numeric":        "должно быть числом",
+		"len":            "должно иметь длину {0}",
+		"in":             "должно быть одним из {*}",
+		"not_in":         "не должно быть одним из {*}",
+		"date":           "должно быть корректной датой в формате {0}",
+		"regex":          "должно соответствовать шаблону {0}",
+		"contains":       "должно содержать {0}",
+		"gt":             "должно быть больше {0}",
+		"lt":             "должно быть меньше {0}",
+		"date_gte":       "должно быть датой больше или равной {1}",
+		"date_lte":       "должно быть датой меньше или равной {1}",
+		"date_gt":        "должно быть датой больше {1}",
+		"date_lt":        "должно быть датой меньше {1}",
+		"has_prefix":     "должно начинаться с {0}",
+		"has_suffix":     "должно заканчиваться на {0}",
+		"has_keys":       "должно содержать ключи {*}",
+		"has_only_keys":  "должно содержать только ключи {*}",
+		"file_exists":    "должно быть существующим файлом",
+		"eq_field":       "должно быть равно {0}",
+		"ne_field":       "не должно быть равно {0}",
+		"gt_field":       "должно быть больше {0}",
+		"gte_field":      "должно быть больше или равно {0}",
+		"lt_field":       "должно быть меньше {0}",
+		"lte_field":      "должно быть меньше или равно {0}",
+		"bool":           "должно быть логическим значением",
+		"type":           "должно быть значением типа {0}",
+
+		"excluded":             "должно быть пустым",
+		"required_if":          "обязательно для заполнения, если {0} равно {1}",
+		"required_unless":      "обязательно для заполнения, если {0} не равно {1}",
+		"required_with":        "обязательно для заполнения, если заполнено {*}",
+		"required_with_all":    "обязательно для заполнения, если заполнены {*}",
+		"required_without":     "обязательно для заполнения, если не заполнено {*}",
+		"required_without_all": "обязательно для заполнения, если не заполнены {*}",
+		"excluded_if":          "должно быть пустым, если {0} равно {1}",
+		"excluded_unless":      "должно быть пустым, если {0} не равно {1}",
+		"excluded_with":        "должно быть пустым, если заполнено {*}",
+		"excluded_without":     "должно быть пустым, если не заполнено {*}",
+	})
+}
+
+// Message catalogs by locale
+var Catalogs = newCatalogs(map[string]*MessageMap{
+	"en": Messages,
+	"ru": russianMessages(),
+})
+
+// Return catalogs of build-in messages
+func defaultCatalogs() *CatalogMap {
+	return newCatalogs(map[string]*MessageMap{
+		"en": englishMessages(),
+		"ru": russianMessages(),
+	})
+}
+
+// Create map of messages
+func newMessages(m map[string]string) *MessageMap {
+	res := &MessageMap{}
+	res.Update(m)
+	return res
+}
 
-var Messages = messages{
-	"required":      "is required",
-	"min":           "must be greater or equal of {0}",
-	"max":           "must be lower or equal of {0}",
-	"in":            "must be in {0}",
-	"email":         "must be a valid email address",
-	"url":           "must be a valid url",
-	"date":          "must be a valid date in {0} format",
-	"country_code2": "must be a valid country code in AA format",
-	"country_code3": "must be a valid country code in AAA format",
+// Create map of messages by locale
+func newCatalogs(m map[string]*MessageMap) *CatalogMap {
+	entries := make(map[string]interface{}, len(m))
+	for locale, messages := range m {
+		entries[normalizeLocale(locale)] = messages
+	}
+
+	res := &CatalogMap{}
+	res.update(entries)
+	return res
 }
 
 // Add validation error message
-func (m messages) Add(rule string, message string) {
-	m[rule] = message
+func (m *MessageMap) Add(rule string, message string) {
+	m.update(map[string]interface{}{rule: message})
 }
 
 // Add or replace validation error messages
-func (m messages) Update(messages map[string]string) {
+func (m *MessageMap) Update(messages map[string]string) {
+	entries := make(map[string]interface{}, len(messages))
 	for rule, message := range messages {
-		m[rule] = message
+		entries[rule] = message
+	}
+	m.update(entries)
+}
+
+// Get message of rule
+func (m *MessageMap) Get(rule string) (string, bool) {
+	if message, ok := m.get(rule); ok {
+		return message.(string), true
+	}
+	return "", false
+}
+
+// Add or replace validation error messages of locale
+func (c *CatalogMap) Add(locale string, m map[string]string) {
+	c.getOrAdd(normalizeLocale(locale), func() interface{} {
+		return &MessageMap{}
+	}).(*MessageMap).Update(m)
+}
+
+// Get messages of locale
+func (c *CatalogMap) Get(locale string) (*MessageMap, bool) {
+	if m, ok := c.get(normalizeLocale(locale)); ok {
+		return m.(*MessageMap), true
 	}
+	return nil, false
+}
+
+// Return message of rule by locale
+// Locales are searched by chain like "de-AT" -> "de" -> "en",
+// messages of locale in loaded maps like messages of configuration take precedence over catalog
+func (c *CatalogMap) message(locale string, rule string, loaded ...map[string]map[string]string) (string, bool) {
+	for _, l := range localeChain(locale) {
+		for _, m := range loaded {
+			if message, ok := m[l][rule]; ok {
+				return message, true
+			}
+		}
+		if m, ok := c.get(l); ok {
+			if message, ok := m.(*MessageMap).Get(rule); ok {
+				return message, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Return chain of locales for searching message
+func localeChain(locale string) []string {
+	var chain []string
+	locale = normalizeLocale(locale)
+	for len(locale) > 0 {
+		chain = append(chain, locale)
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return append(chain, fallbackLocale)
+}
+
+// Convert locale like "de_AT" to "de-at"
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
 }
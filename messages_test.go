@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMessages_Complete(t *testing.T) {
+	for _, locale := range []string{"en", "ru"} {
+		catalog, _ := Catalogs.Get(locale)
+		for _, builtin := range []map[string]Validator{validators, fieldValidators} {
+			for name := range builtin {
+				if _, ok := catalog.Get(name); !ok {
+					t.Errorf("Message for %s not found in %s catalog.", name, locale)
+				}
+			}
+		}
+		for name := range conditionalOptions {
+			if _, ok := catalog.Get(name); !ok {
+				t.Errorf("Message for %s not found in %s catalog.", name, locale)
+			}
+		}
+		for _, option := range []Option{Required, Excluded, Numeric} {
+			if _, ok := catalog.Get(string(option)); !ok {
+				t.Errorf("Message for %s not found in %s catalog.", option, locale)
+			}
+		}
+	}
+}
+
+func TestCatalogs_Message(t *testing.T) {
+	catalogs := New().Catalogs
+	catalogs.Add("de", map[string]string{"required": "ist erforderlich"})
+	catalogs.Add("de_AT", map[string]string{"email": "muss eine gültige E-Mail-Adresse sein"})
+
+	var items = []struct {
+		Locale  string
+		Rule    string
+		Message string
+	}{
+		{Locale: "de-AT", Rule: "email", Message: "muss eine gültige E-Mail-Adresse sein"},
+		{Locale: "de-AT", Rule: "required", Message: "ist erforderlich"},
+		{Locale: "de-AT", Rule: "url", Message: "must be a valid url"},
+		{Locale: "RU", Rule: "url", Message: "должно быть корректным url"},
+		{Locale: "es", Rule: "url", Message: "must be a valid url"},
+	}
+
+	for _, item := range items {
+		if message, _ := catalogs.message(item.Locale, item.Rule); message != item.Message {
+			t.Errorf("Wrong message «%s» for %s in %s.", message, item.Rule, item.Locale)
+		}
+	}
+}
+
+func TestCatalogMap(t *testing.T) {
+	e := New()
+	e.Catalogs = &CatalogMap{}
+	e.Catalogs.Add("en", map[string]string{"required": "can't be blank"})
+
+	messages, ok := e.Catalogs.Get("en")
+	if _, found := messages.Get("email"); !ok || found {
+		t.Error("Wrong messages of new catalogs.")
+	}
+	if errs := e.ValidateValue("", "required"); errs[0].Error() != "can't be blank" {
+		t.Errorf("Catalogs of engine are not used: %s", errs[0])
+	}
+}
+
+func TestErrorList_Translate(t *testing.T) {
+	errs := ValidateValue("x", "in:a,b").Translate("ru")
+
+	if errs[0].Error() != "должно быть одним из a,b" {
+		t.Error(errs[0].Error())
+	}
+}
+
+func TestFieldError_Translate(t *testing.T) {
+	e := New()
+	e.Catalogs.Add("de", map[string]string{"in": "muss einer von {*} sein"})
+	e.Validators.Add("custom", func(value interface{}, options OptionList, params ...interface{}) error {
+		return errors.New("custom message")
+	})
+
+	errs := e.ValidateValue("x", "in:a,b|custom").Translate("de")
+	if errs[0].Error() != "muss einer von a,b sein" {
+		t.Errorf("Catalogs of engine are not used: %s", errs[0])
+	}
+	if errs[1].Error() != "custom message" {
+		t.Errorf("Message of custom validator is replaced: %s", errs[1])
+	}
+	if _, ok := Catalogs.Get("de"); ok {
+		t.Error("Catalogs of engine are shared with package.")
+	}
+}
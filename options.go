@@ -9,17 +9,58 @@ const Ignore Option = "ignore"
 // If this option is present validation will be performed before the first error
 const Lazy Option = "lazy"
 
+// If this option is present value must be empty
+const Excluded Option = "excluded"
+
+// If this option is present string must be a number and it is compared by numeric value
+const Numeric Option = "numeric"
+
 // Validation option
 type Option string
 
 // List of present options
 type OptionList []Option
 
+// Map of possible options
+// It is safe for concurrent use
+type OptionMap struct {
+	registry
+}
+
 // List of possible options
-var Options = OptionList{
-	Required,
-	Ignore,
-	Lazy,
+var Options = defaultOptions()
+
+// Return build-in options
+func defaultOptions() *OptionMap {
+	o := &OptionMap{}
+	o.update(map[string]interface{}{
+		string(Required): Required,
+		string(Ignore):   Ignore,
+		string(Lazy):     Lazy,
+		string(Excluded): Excluded,
+		string(Numeric):  Numeric,
+	})
+	return o
+}
+
+// Check what option exists
+func (o *OptionMap) Has(name Option) bool {
+	_, ok := o.get(string(name))
+	return ok
+}
+
+// Get option by name
+func (o *OptionMap) Get(name string) (Option, bool) {
+	if option, ok := o.get(name); ok {
+		return option.(Option), true
+	}
+	return Option(""), false
+}
+
+// Add new option
+func (o *OptionMap) Add(option Option) {
+	o.update(map[string]interface{}{string(option): option})
+	resetCache()
 }
 
 // Check what option exists
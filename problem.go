@@ -0,0 +1,244 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Media type of problem details documents
+const ProblemContentType = "application/problem+json"
+
+// Problem details document of RFC 9457 with validation errors
+type Problem struct {
+	Type     string         `json:"type,omitempty"`
+	Title    string         `json:"title,omitempty"`
+	Status   int            `json:"status,omitempty"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []ProblemError `json:"errors"`
+}
+
+// Validation error of problem document
+// Pointer is JSON Pointer of RFC 6901 to the field like "/customer/email".
+// Field is path of error what can not be restored from pointer like "Tags[color]", it is empty for other paths
+type ProblemError struct {
+	Pointer string        `json:"pointer"`
+	Field   string        `json:"field,omitempty"`
+	Detail  string        `json:"detail"`
+	Code    string        `json:"code,omitempty"`
+	Params  []interface{} `json:"params,omitempty"`
+}
+
+// Type of problem documents what have no own type, their title is text of status
+const blankProblemType = "about:blank"
+
+// Default type and status of problem documents
+// Title is text of status if it is not specified or type is "about:blank"
+var DefaultProblem = Problem{
+	Type:   blankProblemType,
+	Status: http.StatusUnprocessableEntity,
+}
+
+// Returns problem document with errors of map
+// Not empty fields of template replace fields of DefaultProblem.
+// Configuration errors are not exposed, document with status 500 and without errors is returned for them
+func (e ErrorMap) Problem(template ...Problem) *Problem {
+	if len(e.ConfigErrors()) > 0 {
+		return serverProblem(template)
+	}
+	res := newProblem(template)
+
+	keys := make([]string, 0, len(e))
+	for key := range e {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		res.Errors = appendProblemErrors(res.Errors, key, e[key])
+	}
+	return res
+}
+
+// Returns problem document representation of ErrorMap
+func (e ErrorMap) ProblemJSON(template ...Problem) string {
+	b, _ := json.Marshal(e.Problem(template...))
+	return string(b)
+}
+
+// Returns problem document with errors of list
+// Errors refer to the whole document
+func (e ErrorList) Problem(template ...Problem) *Problem {
+	if len(e.ConfigErrors()) > 0 {
+		return serverProblem(template)
+	}
+	res := newProblem(template)
+	res.Errors = appendProblemErrors(res.Errors, "", e)
+	return res
+}
+
+// Returns problem document representation of ErrorList
+func (e ErrorList) ProblemJSON(template ...Problem) string {
+	b, _ := json.Marshal(e.Problem(template...))
+	return string(b)
+}
+
+// Parse problem document
+func ParseProblem(data []byte) (*Problem, error) {
+	var res Problem
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Returns errors of problem document by paths of fields
+func (p *Problem) ErrorMap() ErrorMap {
+	res := ErrorMap{}
+	for _, item := range p.Errors {
+		path := item.Field
+		if len(path) == 0 {
+			path = PointerPath(item.Pointer)
+		}
+		res[path] = append(res[path], &FieldError{
+			Field:   path,
+			Rule:    item.Code,
+			Params:  item.Params,
+			Message: item.Detail,
+		})
+	}
+	return res
+}
+
+// Convert path of field like "Items[0].Name" to JSON Pointer like "/Items/0/Name"
+func JSONPointer(path string) string {
+	var b strings.Builder
+	for _, segment := range splitPath(path) {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(segment))
+	}
+	return b.String()
+}
+
+// Convert JSON Pointer like "/Items/0/Name" to path of field like "Items[0].Name"
+// Numeric segments are converted to indexes, others to names of fields. Pointer does not tell keys of maps
+// from fields, so "/Tags/color" becomes "Tags.color" even if error was stored by "Tags[color]"
+func PointerPath(pointer string) string {
+	if len(pointer) == 0 {
+		return ""
+	}
+
+	var path string
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		if _, err := strconv.Atoi(segment); err == nil && len(path) > 0 {
+			path += "[" + segment + "]"
+		} else {
+			path = joinPath(path, segment)
+		}
+	}
+	return path
+}
+
+// Split path of field like "Items[0].Name" to segments
+func splitPath(path string) []string {
+	var res []string
+	var segment strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[' && depth == 0:
+			if segment.Len() > 0 {
+				res = append(res, segment.String())
+				segment.Reset()
+			}
+			depth++
+		case r == ']' && depth == 1:
+			res = append(res, segment.String())
+			segment.Reset()
+			depth--
+		case r == '.' && depth == 0:
+			if segment.Len() > 0 {
+				res = append(res, segment.String())
+				segment.Reset()
+			}
+		default:
+			if r == '[' {
+				depth++
+			} else if r == ']' {
+				depth--
+			}
+			segment.WriteRune(r)
+		}
+	}
+	if segment.Len() > 0 {
+		res = append(res, segment.String())
+	}
+	return res
+}
+
+// Return problem document with fields of template
+func newProblem(template []Problem) *Problem {
+	res := DefaultProblem
+	res.Errors = []ProblemError{}
+	for _, t := range template {
+		if len(t.Type) > 0 {
+			res.Type = t.Type
+		}
+		if len(t.Title) > 0 {
+			res.Title = t.Title
+		}
+		if t.Status > 0 {
+			res.Status = t.Status
+		}
+		if len(t.Detail) > 0 {
+			res.Detail = t.Detail
+		}
+		if len(t.Instance) > 0 {
+			res.Instance = t.Instance
+		}
+	}
+	if len(res.Type) == 0 || res.Type == blankProblemType {
+		res.Type = blankProblemType
+		res.Title = http.StatusText(res.Status)
+	} else if len(res.Title) == 0 {
+		res.Title = http.StatusText(res.Status)
+	}
+	return &res
+}
+
+// Return problem document of configuration errors, only instance of template is kept
+func serverProblem(template []Problem) *Problem {
+	res := newProblem(template)
+	return &Problem{
+		Type:     blankProblemType,
+		Title:    http.StatusText(http.StatusInternalServerError),
+		Status:   http.StatusInternalServerError,
+		Instance: res.Instance,
+		Errors:   []ProblemError{},
+	}
+}
+
+// Append errors of list by path to problem errors
+// Path is kept if pointer does not restore it
+func appendProblemErrors(res []ProblemError, path string, list ErrorList) []ProblemError {
+	pointer := JSONPointer(path)
+	var field string
+	if PointerPath(pointer) != path {
+		field = path
+	}
+
+	for _, err := range list.FieldErrors() {
+		res = append(res, ProblemError{
+			Pointer: pointer,
+			Field:   field,
+			Detail:  err.Message,
+			Code:    err.Rule,
+			Params:  err.Params,
+		})
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONPointer(t *testing.T) {
+	var items = []struct {
+		Path    string
+		Pointer string
+	}{
+		{Path: "", Pointer: ""},
+		{Path: "customer.email", Pointer: "/customer/email"},
+		{Path: "Items[0].Name", Pointer: "/Items/0/Name"},
+		{Path: "Matrix[1][2]", Pointer: "/Matrix/1/2"},
+		{Path: "Meta[a.b]", Pointer: "/Meta/a.b"},
+		{Path: "Meta[a/b~c]", Pointer: "/Meta/a~1b~0c"},
+	}
+
+	for _, item := range items {
+		if pointer := JSONPointer(item.Path); pointer != item.Pointer {
+			t.Errorf("Wrong pointer of «%s»: %s", item.Path, pointer)
+		}
+	}
+
+	for _, path := range []string{"", "customer.email", "Items[0].Name", "Matrix[1][2]"} {
+		if res := PointerPath(JSONPointer(path)); res != path {
+			t.Errorf("Wrong path of «%s»: %s", path, res)
+		}
+	}
+
+	// keys of maps are not told from fields by pointer
+	if res := PointerPath(JSONPointer("Tags[color]")); res != "Tags.color" {
+		t.Errorf("Wrong path of map key: %s", res)
+	}
+}
+
+func TestErrorMap_Problem(t *testing.T) {
+	errs := ErrorMap{
+		"customer.email": ErrorList{errorMessage("email")},
+		"items[0].qty":   ErrorList{errorMessage("min", "1")},
+		"":               ErrorList{errors.New("order is closed")},
+	}
+
+	r := `{"type":"https://example.com/problems/validation","title":"Your request is not valid.","status":422,"errors":[` +
+		`{"pointer":"","detail":"order is closed"},` +
+		`{"pointer":"/customer/email","detail":"must be a valid email address","code":"email"},` +
+		`{"pointer":"/items/0/qty","detail":"must be greater or equal of 1","code":"min","params":["1"]}]}`
+
+	if res := errs.ProblemJSON(Problem{Type: "https://example.com/problems/validation", Title: "Your request is not valid."}); res != r {
+		t.Errorf("Wrong problem document: %s", res)
+	}
+
+	if res := (ErrorMap{}).ProblemJSON(Problem{Status: 400, Title: "Bad"}); res != `{"type":"about:blank","title":"Bad Request","status":400,"errors":[]}` {
+		t.Errorf("Wrong empty problem document: %s", res)
+	}
+	if res := (ErrorMap{}).ProblemJSON(Problem{Type: "https://example.com/problems/validation"}); res != `{"type":"https://example.com/problems/validation","title":"Unprocessable Entity","status":422,"errors":[]}` {
+		t.Errorf("Wrong problem document without title: %s", res)
+	}
+
+	errs["items[0].qty"] = append(errs["items[0].qty"], &ConfigError{Rule: "fake_rule", Err: ErrRuleNotFound})
+	r = `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/orders/1","errors":[]}`
+	if res := errs.ProblemJSON(Problem{Type: "https://example.com/problems/validation", Instance: "/orders/1"}); res != r {
+		t.Errorf("Wrong problem document of configuration errors: %s", res)
+	}
+	if res := errs["items[0].qty"].Problem(); res.Status != 500 || len(res.Errors) != 0 {
+		t.Errorf("Wrong problem of configuration errors of list: %+v", res)
+	}
+}
+
+func TestParseProblem(t *testing.T) {
+	errs := ValidateStruct(struct {
+		Name  string `valid:"min:5"`
+		Items []int  `valid:"dive|gt:0"`
+	}{Name: "abc", Items: []int{1, -1}})
+
+	problem, err := ParseProblem([]byte(errs.ProblemJSON()))
+	if err != nil || problem.Status != 422 || len(problem.Errors) != 2 {
+		t.Fatalf("Wrong problem: %+v %v", problem, err)
+	}
+
+	res := problem.ErrorMap()
+	if len(res) != 2 || res.JSON() != errs.JSON() {
+		t.Errorf("Wrong errors: %s", res.JSON())
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(res["Name"][0], &fieldErr) || fieldErr.Rule != "min" || !reflect.DeepEqual(fieldErr.Params, []interface{}{"5"}) {
+		t.Errorf("Wrong field error: %+v", fieldErr)
+	}
+
+	errs = ValidateStruct(struct {
+		Tags  map[string]string `valid:"dive|min:3"`
+		Codes map[int]string    `valid:"dive|min:3"`
+	}{Tags: map[string]string{"color": "x"}, Codes: map[int]string{7: "x"}})
+
+	document := errs.ProblemJSON()
+	if !strings.Contains(document, `{"pointer":"/Tags/color","field":"Tags[color]",`) || !strings.Contains(document, `{"pointer":"/Codes/7","detail"`) {
+		t.Errorf("Wrong problem of map keys: %s", document)
+	}
+	problem, _ = ParseProblem([]byte(document))
+	if res := problem.ErrorMap(); res.JSON() != errs.JSON() {
+		t.Errorf("Paths of map keys are not restored: %s", res.JSON())
+	}
+
+	if _, err := ParseProblem([]byte("{")); err == nil {
+		t.Error("Error of malformed document not returned.")
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Map of named entries safe for concurrent use
+// Readers use current snapshot without locking, writers replace snapshot by changed copy
+type registry struct {
+	mu       sync.Mutex   // serializes writers
+	snapshot atomic.Value // map[string]interface{}
+}
+
+// Return current snapshot, it must not be changed
+func (r *registry) load() map[string]interface{} {
+	entries, _ := r.snapshot.Load().(map[string]interface{})
+	return entries
+}
+
+// Get entry by name
+func (r *registry) get(name string) (interface{}, bool) {
+	entry, ok := r.load()[name]
+	return entry, ok
+}
+
+// Add or replace entries
+func (r *registry) update(entries map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.load()
+	next := make(map[string]interface{}, len(current)+len(entries))
+	for name, entry := range current {
+		next[name] = entry
+	}
+	for name, entry := range entries {
+		next[name] = entry
+	}
+	r.snapshot.Store(next)
+}
+
+// Get entry by name or add entry created by function
+func (r *registry) getOrAdd(name string, create func() interface{}) interface{} {
+	if entry, ok := r.get(name); ok {
+		return entry
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.load()
+	if entry, ok := current[name]; ok {
+		return entry
+	}
+
+	next := make(map[string]interface{}, len(current)+1)
+	for n, entry := range current {
+		next[n] = entry
+	}
+	next[name] = create()
+	r.snapshot.Store(next)
+	return next[name]
+}
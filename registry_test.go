@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestRegistry_Concurrent(t *testing.T) {
+	s := struct {
+		Name  string `valid:"required|race_validator"`
+		Email string `valid:"email"`
+	}{Name: " x ", Email: "wrong"}
+
+	Validators.Add("race_validator", func(v interface{}, options OptionList, params ...interface{}) error {
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				name := fmt.Sprintf("race_%d_%d", i, j)
+				Validators.Add(name, func(v interface{}, options OptionList, params ...interface{}) error {
+					return errors.New("error")
+				})
+				Actions.Add(name, Trim)
+				Options.Add(Option(name))
+				Messages.Add(name, "message")
+				Catalogs.Add("race", map[string]string{name: "message"})
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if errs := ValidateStruct(s); len(errs["Email"]) != 1 {
+					t.Error("Unexpected errors:", errs)
+					return
+				}
+				ValidateValue("x", "race_validator")
+				TestSyntax("race_validator|trim")
+				ValidateValue("", "required").Translate("race")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !Validators.Has("race_7_99") || !Actions.Has("race_7_99") || !Options.Has("race_7_99") {
+		t.Error("Registered rules are lost.")
+	}
+	if message, _ := Catalogs.message("race", "race_0_0"); message != "message" {
+		t.Error("Registered message is lost.")
+	}
+}
@@ -1,6 +1,10 @@
 package validation
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 // Validation rule
 type Rule struct {
@@ -23,6 +27,10 @@ func (r *Rule) Exists() bool {
 		return true
 	}
 
+	if _, exists := r.Condition(); exists {
+		return true
+	}
+
 	return false
 }
 
@@ -32,7 +40,11 @@ func (r *Rule) Validator() (Validator, bool) {
 		return validator, ok
 	}
 
-	if validator, ok := Validators[r.Name]; ok {
+	if validator, ok := fieldValidators[r.Name]; ok {
+		return validator, ok
+	}
+
+	if validator, ok := Validators.Get(r.Name); ok {
 		return validator, ok
 	}
 
@@ -41,24 +53,28 @@ func (r *Rule) Validator() (Validator, bool) {
 
 // Get Option if it exists
 func (r *Rule) Option() (Option, bool) {
-	for _, o := range Options {
-		if r.Name == string(o) {
-			return o, true
-		}
-	}
-
-	return Option(""), false
+	return Options.Get(r.Name)
 }
 
 // Get Action if it exists
 func (r *Rule) Action() (Action, bool) {
-	if action, ok := Actions[r.Name]; ok {
+	if action, ok := Actions.Get(r.Name); ok {
 		return action, ok
 	}
 
 	return nil, false
 }
 
+// Get conditional option if it exists
+// Rule can turn on option like "required" if condition on other fields is met
+func (r *Rule) Condition() (Wrapper, bool) {
+	if c, ok := conditionalOptions[r.Name]; ok {
+		return Wrapper{Name: r.Name, Function: c.Condition, Params: r.Params, CrossField: true, Option: c.Option}, true
+	}
+
+	return Wrapper{}, false
+}
+
 // Check what validator is built in
 // todo maybe Option and Action check to ?
 func (r *Rule) IsBuiltin() bool {
@@ -66,32 +82,221 @@ func (r *Rule) IsBuiltin() bool {
 	return ok
 }
 
+// Check what validator is built in and compares value with another field of struct
+func (r *Rule) IsCrossField() bool {
+	_, ok := fieldValidators[r.Name]
+	return ok
+}
+
 // Parse string of rules
+// It panics with *ParseError if string is malformed, use ParseRules to get the error
+//
+// Grammar:
+// rules  = rule { "|" rule }
+// rule   = name [ ":" params ]
+// params = param { "," param }
+// param  = quoted | bare
+// quoted = ( "'" { char } "'" ) | ( '"' { char } '"' )
+//
 // | - rule separator
-// : - split up rule name and parameters
-// , - split up parameters
-// Example "required|max:255|in:x,y,z"
+// : - split up rule name and parameters, next colons belong to parameters
+// , - split up parameters, parameter of "regex" rule is not split
+// Spaces around names and bare parameters are trimmed, empty rules are skipped
+// Quoted parameter is taken as is and can contain separators
+// Backslash escapes separators, quotes and backslash, before another characters it is kept
+// Parameter of "regex" is raw: backslash is removed only before "|" or quote of quoted parameter
+// Example: required|max:255|in:x,y,z|in:'a,b','c'|has_prefix:a\|b
 func Parse(s string) []Rule {
-	var r []Rule
-
-	for _, f := range strings.Split(s, "|") {
-		p := strings.SplitN(f, ":", 2)
-		// todo trim
-		if len(p) == 1 {
-			r = append(r, Rule{Name: p[0]})
-			continue
+	rules, err := ParseRules(s)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// Parse string of rules
+// It returns *ParseError with column of malformed part
+func ParseRules(s string) ([]Rule, error) {
+	p := parser{input: []rune(s)}
+	return p.rules()
+}
+
+// Error of parsing rules string
+type ParseError struct {
+	Rules   string // parsed string
+	Column  int    // position of error in characters starting with 1
+	Message string
+}
+
+// Error message with column
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse rules %q: column %d: %s", e.Rules, e.Column, e.Message)
+}
+
+// Tokenizer of rules string
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) rules() ([]Rule, error) {
+	var res []Rule
+
+	for {
+		rule, err := p.rule()
+		if err != nil {
+			return nil, err
+		}
+		if len(rule.Name) > 0 {
+			res = append(res, rule)
 		}
 
-		if p[0] == "regex" {
-			r = append(r, Rule{Name: "regex", Params: []interface{}{p[1]}})
+		if p.eof() {
+			return res, nil
 		}
+		p.pos++ // rule separator
+	}
+}
 
-		a := strings.Split(p[1], ",")
-		pr := make([]interface{}, len(a))
-		for i, v := range a {
-			pr[i] = v
+func (p *parser) rule() (Rule, error) {
+	p.skipSpaces()
+	start := p.pos
+
+	for !p.eof() && p.current() != '|' && p.current() != ':' {
+		if c := p.current(); c == '\'' || c == '"' || c == '\\' || c == ',' {
+			return Rule{}, p.error(p.pos, fmt.Sprintf("unexpected %q in rule name", c))
 		}
-		r = append(r, Rule{Name: p[0], Params: pr})
+		p.pos++
+	}
+
+	rule := Rule{Name: strings.TrimSpace(string(p.input[start:p.pos]))}
+	if p.eof() || p.current() == '|' {
+		return rule, nil
 	}
-	return r
+
+	if len(rule.Name) == 0 {
+		return Rule{}, p.error(p.pos, "rule name expected before \":\"")
+	}
+	if strings.IndexFunc(rule.Name, unicode.IsSpace) >= 0 {
+		return Rule{}, p.error(start, fmt.Sprintf("unexpected space in rule name %q", rule.Name))
+	}
+	p.pos++ // name separator
+
+	for {
+		param, err := p.param(rule.Name == "regex")
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Params = append(rule.Params, param)
+
+		if p.eof() || p.current() == '|' {
+			return rule, nil
+		}
+		p.pos++ // parameters separator
+	}
+}
+
+// Read parameter, whole parameter is read to the rule separator in raw mode
+// Backslashes of raw parameter are kept except the one before rule separator, so patterns keep their meaning
+func (p *parser) param(raw bool) (string, error) {
+	p.skipSpaces()
+	if !p.eof() && (p.current() == '\'' || p.current() == '"') {
+		return p.quoted(raw)
+	}
+
+	var b strings.Builder
+	for !p.eof() {
+		switch c := p.current(); {
+		case c == '\\':
+			if p.pos+1 >= len(p.input) {
+				return "", p.error(p.pos, "escaped character expected after \"\\\"")
+			}
+			next := p.input[p.pos+1]
+			if raw && next != '|' {
+				b.WriteRune(c)
+				if next == '\\' {
+					b.WriteRune(next) // escaped backslash of pattern does not escape separator
+					p.pos++
+				}
+				break
+			}
+			if strings.ContainsRune(escapedChars, next) {
+				b.WriteRune(next)
+				p.pos += 2
+				continue
+			}
+			b.WriteRune(c)
+		case c == '|' || c == ',' && !raw:
+			return strings.TrimSpace(b.String()), nil
+		default:
+			b.WriteRune(c)
+		}
+		p.pos++
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// Read quoted parameter
+// Only backslash before the quote is removed in raw mode
+func (p *parser) quoted(raw bool) (string, error) {
+	start := p.pos
+	quote := p.current()
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.error(start, fmt.Sprintf("unterminated quoted parameter, %q expected", quote))
+		}
+
+		c := p.current()
+		if c == quote {
+			p.pos++
+			break
+		}
+		if c == '\\' && p.pos+1 < len(p.input) && raw {
+			switch p.input[p.pos+1] {
+			case quote:
+				p.pos++
+				c = p.current()
+			case '\\':
+				b.WriteRune(c) // escaped backslash of pattern does not escape quote
+				p.pos++
+			}
+		} else if c == '\\' && p.pos+1 < len(p.input) && strings.ContainsRune(escapedChars, p.input[p.pos+1]) {
+			p.pos++
+			c = p.current()
+		}
+		b.WriteRune(c)
+		p.pos++
+	}
+
+	p.skipSpaces()
+	if !p.eof() && p.current() != '|' && p.current() != ',' {
+		return "", p.error(p.pos, fmt.Sprintf("unexpected %q after quoted parameter", p.current()))
+	}
+
+	return b.String(), nil
+}
+
+// Characters what are escaped by backslash
+const escapedChars = "|,'\"\\"
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) current() rune {
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.current()) {
+		p.pos++
+	}
+}
+
+func (p *parser) error(pos int, message string) *ParseError {
+	return &ParseError{Rules: string(p.input), Column: pos + 1, Message: message}
 }
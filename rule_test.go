@@ -1,6 +1,9 @@
 package validation
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestRule_Exists(t *testing.T) {
 	true1 := Rule{Name: "required"}
@@ -66,10 +69,62 @@ func TestRule_IsBuiltin(t *testing.T) {
 	}
 }
 
-func TestParse(t *testing.T) { //todo more cases!
+func TestParse(t *testing.T) {
 	s := "required|max:255|in:x,y,z"
 	r := Parse(s)
 	if len(r) != 3 {
 		t.Error("Error parsing rules.")
 	}
+
+	var items = []struct {
+		Rules  string
+		Parsed []Rule
+	}{
+		{Rules: " required | max: 255 ||", Parsed: []Rule{{Name: "required"}, {Name: "max", Params: []interface{}{"255"}}}},
+		{Rules: "in:'a,b', \"c|d\" ,e", Parsed: []Rule{{Name: "in", Params: []interface{}{"a,b", "c|d", "e"}}}},
+		{Rules: "in:'it\\'s',x\\,y", Parsed: []Rule{{Name: "in", Params: []interface{}{"it's", "x,y"}}}},
+		{Rules: "has_prefix:a\\|b|lazy", Parsed: []Rule{{Name: "has_prefix", Params: []interface{}{"a|b"}}, {Name: "lazy"}}},
+		{Rules: "regex:^\\d{1,3}$|max:5", Parsed: []Rule{{Name: "regex", Params: []interface{}{"^\\d{1,3}$"}}, {Name: "max", Params: []interface{}{"5"}}}},
+		{Rules: "regex:'^(a|b)$'", Parsed: []Rule{{Name: "regex", Params: []interface{}{"^(a|b)$"}}}},
+		{Rules: "time:15:04,", Parsed: []Rule{{Name: "time", Params: []interface{}{"15:04", ""}}}},
+		{Rules: `regex:^a\\b,\d$`, Parsed: []Rule{{Name: "regex", Params: []interface{}{`^a\\b,\d$`}}}},
+		{Rules: `regex:^a\\|lazy`, Parsed: []Rule{{Name: "regex", Params: []interface{}{`^a\\`}}, {Name: "lazy"}}},
+		{Rules: `regex:^(a\|b)$`, Parsed: []Rule{{Name: "regex", Params: []interface{}{`^(a|b)$`}}}},
+		{Rules: `regex:'^\'\\\d\|'`, Parsed: []Rule{{Name: "regex", Params: []interface{}{`^'\\\d\|`}}}},
+	}
+
+	for _, item := range items {
+		if parsed, err := ParseRules(item.Rules); err != nil || !reflect.DeepEqual(parsed, item.Parsed) {
+			t.Errorf("Wrong rules of «%s»: %#v %v", item.Rules, parsed, err)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	var items = []struct {
+		Rules  string
+		Column int
+	}{
+		{Rules: "in:'a,b", Column: 4},
+		{Rules: "in:\"a\"b", Column: 7},
+		{Rules: "required|:x", Column: 10},
+		{Rules: "max len:5", Column: 1},
+		{Rules: "ma'x:5", Column: 3},
+		{Rules: "in:a\\", Column: 5},
+		{Rules: "in:'ж',  'ж", Column: 10},
+	}
+
+	for _, item := range items {
+		_, err := ParseRules(item.Rules)
+		if parseErr, ok := err.(*ParseError); !ok || parseErr.Column != item.Column {
+			t.Errorf("Wrong error of «%s»: %v", item.Rules, err)
+		}
+	}
+
+	defer func() {
+		if _, ok := recover().(*ParseError); !ok {
+			t.Error("Parse does not panic.")
+		}
+	}()
+	Parse("in:'a")
 }
@@ -0,0 +1,335 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Configuration of rule sets and messages
+// Types are rules of default tag of engine by type and field names like {"User": {"Bio": "max:500"}},
+// scenarios are rules of another tags like {"on_create": {"User": {"Password": "required"}}}.
+// Rules are added to rules of struct tags or replace them if Replace is true.
+// Messages by locale like {"en": {"max": "is too long"}} take precedence over catalogs
+type RuleConfig struct {
+	Replace   bool                                    `json:"replace" yaml:"replace"`
+	Types     map[string]map[string]string            `json:"types" yaml:"types"`
+	Scenarios map[string]map[string]map[string]string `json:"scenarios" yaml:"scenarios"`
+	Messages  map[string]map[string]string            `json:"messages" yaml:"messages"`
+}
+
+// Function what decodes configuration file like json.Unmarshal
+// Package has no dependencies, so YAML decoder like yaml.Unmarshal of gopkg.in/yaml.v3 is passed by user
+type DecodeFunc func(data []byte, v interface{}) error
+
+// Rule sets loaded from configuration file
+// It is safe for concurrent use, Reload replaces all rules and messages at once
+type RuleSet struct {
+	Path   string
+	Decode DecodeFunc // json.Unmarshal is used if it is nil
+
+	engine   *Engine        // engine of rules, default engine is used if it is nil
+	mu       sync.Mutex     // serializes loading
+	snapshot atomic.Value   // *ruleSnapshot
+	modTime  time.Time      // modification time of last read file
+	size     int64          // size of last read file, edits within the same modification time change it
+	types    []reflect.Type // types what rules are checked against
+}
+
+// Loaded rules by tag, type and field and messages by locale
+type ruleSnapshot struct {
+	replace  bool
+	tags     map[string]map[string]map[string]string
+	messages map[string]map[string]string
+}
+
+// Rule sets of package functions, it has no rules until they are loaded
+var Rules = &RuleSet{}
+
+// Errors of checking rule sets against types
+var (
+	ErrUnknownType  = errors.New("unknown type")
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// Load rule sets from configuration file for package functions
+// Custom validators must be added before loading, rules are checked by TestSyntax
+func LoadRules(path string, decode DecodeFunc) (*RuleSet, error) {
+	if err := Rules.load(path, decode); err != nil {
+		return nil, err
+	}
+	return Rules, nil
+}
+
+// Load rule sets from configuration file for engine
+// Rule set of engine is created by the first call, so it must be done before engine is used concurrently.
+// Next calls load rules to the same rule set
+func (e *Engine) LoadRules(path string, decode DecodeFunc) (*RuleSet, error) {
+	r := e.Rules
+	if r == nil {
+		r = &RuleSet{engine: e}
+	}
+	if err := r.load(path, decode); err != nil {
+		return nil, err
+	}
+	e.Rules = r
+	return r, nil
+}
+
+// Read configuration file again and replace rules
+// Rules are kept if file can not be loaded
+func (r *RuleSet) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reload()
+}
+
+// Check what types and fields of loaded rules exist in types
+// Types are kept, so rules of next reloads are checked against them too
+func (r *RuleSet) Check(types ...interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range types {
+		typeOf := reflect.TypeOf(t)
+		for typeOf != nil && typeOf.Kind() == reflect.Ptr {
+			typeOf = typeOf.Elem()
+		}
+		if typeOf == nil || typeOf.Kind() != reflect.Struct {
+			panic(errorWrongType)
+		}
+		r.types = append(r.types, typeOf)
+	}
+	return r.current().check(r.types)
+}
+
+// Reload rules when modification time or size of file is changed
+// File is checked every interval until context is done, errors of reloading are passed to onError.
+// File what fails is not reloaded until it is changed, the same error of reading is passed once
+func (r *RuleSet) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		info, err := os.Stat(r.Path)
+		changed := err == nil && (!info.ModTime().Equal(r.modTime) || info.Size() != r.size)
+		if changed {
+			err = r.reload()
+		}
+		r.mu.Unlock()
+
+		switch {
+		case err == nil:
+			lastErr = ""
+		case err.Error() != lastErr || changed:
+			lastErr = err.Error()
+			if onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Set file of rule set and load it
+// File and decoder are kept if file can not be loaded
+func (r *RuleSet) load(path string, decode DecodeFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prevPath, prevDecode := r.Path, r.Decode
+	r.Path, r.Decode = path, decode
+	if err := r.reload(); err != nil {
+		r.Path, r.Decode = prevPath, prevDecode
+		return err
+	}
+	return nil
+}
+
+// Read configuration file and replace rules, lock must be held
+// Modification time is stored even if file is not valid, so it is not reloaded until it is changed
+func (r *RuleSet) reload() error {
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return fmt.Errorf("load rules: %w", err)
+	}
+	data, err := os.ReadFile(r.Path)
+	if err != nil {
+		return fmt.Errorf("load rules: %w", err)
+	}
+
+	r.modTime, r.size = info.ModTime(), info.Size()
+	if err := r.apply(data); err != nil {
+		return fmt.Errorf("load rules %s: %w", r.Path, err)
+	}
+	return nil
+}
+
+// Decode configuration, check its rules and replace loaded rules
+func (r *RuleSet) apply(data []byte) error {
+	decode := r.Decode
+	if decode == nil {
+		decode = json.Unmarshal
+	}
+
+	var config RuleConfig
+	if err := decode(data, &config); err != nil {
+		return err
+	}
+
+	engine := r.engine
+	if engine == nil {
+		engine = defaultEngine()
+	}
+
+	snapshot := &ruleSnapshot{
+		replace:  config.Replace,
+		tags:     make(map[string]map[string]map[string]string),
+		messages: make(map[string]map[string]string),
+	}
+	for tag, types := range config.Scenarios {
+		snapshot.add(tag, types)
+	}
+	snapshot.add(engine.Tag, config.Types)
+
+	for tag, types := range snapshot.tags {
+		for typeName, fields := range types {
+			for field, rules := range fields {
+				if errs := engine.TestSyntax(rules); len(errs) > 0 {
+					errs[0].Field = typeName + "." + field
+					errs[0].Tag = tag
+					return errs[0]
+				}
+			}
+		}
+	}
+	if err := snapshot.check(r.types); err != nil {
+		return err
+	}
+
+	for locale, messages := range config.Messages {
+		locale = normalizeLocale(locale)
+		if snapshot.messages[locale] == nil {
+			snapshot.messages[locale] = make(map[string]string)
+		}
+		for rule, message := range messages {
+			snapshot.messages[locale][rule] = message
+		}
+	}
+	r.snapshot.Store(snapshot)
+	engine.cache.structs.clear() // plans of previous rules are not used anymore
+	return nil
+}
+
+// Return loaded rules, it is nil for nil rule set
+func (r *RuleSet) current() *ruleSnapshot {
+	if r == nil {
+		return nil
+	}
+	snapshot, _ := r.snapshot.Load().(*ruleSnapshot)
+	return snapshot
+}
+
+// Return loaded messages by locale, it is nil for nil snapshot
+func (s *ruleSnapshot) localeMessages() map[string]map[string]string {
+	if s == nil {
+		return nil
+	}
+	return s.messages
+}
+
+// Check what types and fields of rules exist in types
+// Errors are sorted by names, so the first one is returned
+func (s *ruleSnapshot) check(types []reflect.Type) error {
+	if s == nil || len(types) == 0 {
+		return nil
+	}
+
+	var errs []*ConfigError
+	for _, typesRules := range s.tags {
+		for typeName, fields := range typesRules {
+			typeOf := findType(types, typeName)
+			if typeOf == nil {
+				errs = append(errs, &ConfigError{Field: typeName, Err: ErrUnknownType})
+				continue
+			}
+			for field := range fields {
+				if _, ok := typeOf.FieldByName(field); !ok {
+					errs = append(errs, &ConfigError{Field: typeName + "." + field, Err: ErrUnknownField})
+				}
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+	return errs[0]
+}
+
+// Return type by full name like "models.User" or by short name like "User"
+func findType(types []reflect.Type, name string) reflect.Type {
+	for _, typeOf := range types {
+		if typeOf.String() == name || typeOf.Name() == name {
+			return typeOf
+		}
+	}
+	return nil
+}
+
+// Add rules of types to tag
+func (s *ruleSnapshot) add(tag string, types map[string]map[string]string) {
+	if s.tags[tag] == nil {
+		s.tags[tag] = make(map[string]map[string]string)
+	}
+	for typeName, fields := range types {
+		if s.tags[tag][typeName] == nil {
+			s.tags[tag][typeName] = make(map[string]string)
+		}
+		for field, rules := range fields {
+			s.tags[tag][typeName][field] = rules
+		}
+	}
+}
+
+// Return rules of tag with loaded rules of field
+// Types are found by full name like "models.User" or by short name like "User"
+func (s *ruleSnapshot) rules(tag string, typeOf reflect.Type, field string, tagValue string) string {
+	if s == nil {
+		return tagValue
+	}
+
+	types := s.tags[tag]
+	fields, ok := types[typeOf.String()]
+	if !ok {
+		fields = types[typeOf.Name()]
+	}
+	rules, ok := fields[field]
+	switch {
+	case !ok:
+		return tagValue
+	case s.replace || len(tagValue) == 0:
+		return rules
+	case len(rules) == 0:
+		return tagValue
+	default:
+		return tagValue + "|" + rules
+	}
+}
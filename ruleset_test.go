@@ -0,0 +1,274 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testProfile struct {
+	Bio      string `valid:"max:1000"`
+	Currency string
+	Password string `on_create:"min:6"`
+}
+
+func writeRules(t *testing.T, path string, data string) {
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEngine_LoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{
+		"types": {"testProfile": {"Bio": "max:5", "Currency": "in:USD,EUR"}},
+		"scenarios": {"on_create": {"validation.testProfile": {"Password": "required"}}},
+		"messages": {"en": {"in": "is not allowed"}}
+	}`)
+
+	e := New()
+	rules, err := e.LoadRules(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := testProfile{Bio: "abcdefg", Currency: "RUB"}
+	r := `{"Bio":["must be lower or equal of 5"],"Currency":["is not allowed"],"Password":["is required"]}`
+	if errs := e.ValidateStruct(profile, "valid", "on_create"); errs.JSON() != r {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+
+	fields := e.InspectStruct(profile)
+	if fields[0].Rules != "max:1000|max:5" || fields[1].Rules != "in:USD,EUR" {
+		t.Errorf("Wrong rules of fields: %+v", fields)
+	}
+
+	writeRules(t, path, `{"replace": true, "types": {"testProfile": {"Bio": "max:10"}}}`)
+	if err := rules.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if errs := e.ValidateStruct(profile); !errs.Empty() {
+		t.Errorf("Wrong errors after reload: %s", errs.JSON())
+	}
+	if fields := e.InspectStruct(profile); fields[0].Rules != "max:10" {
+		t.Errorf("Wrong replaced rules: %+v", fields[0])
+	}
+
+	writeRules(t, path, `{"types": {"testProfile": {"Bio": "max:1|fake_rule"}}}`)
+	if err := rules.Reload(); err == nil || !strings.Contains(err.Error(), `testProfile.Bio (tag "valid"): rule "fake_rule"`) {
+		t.Errorf("Wrong error of reload: %v", err)
+	}
+	if fields := e.InspectStruct(profile); fields[0].Rules != "max:10" {
+		t.Errorf("Rules are changed by failed reload: %+v", fields[0])
+	}
+
+	writeRules(t, path, `{"types":`)
+	if err := rules.Reload(); err == nil {
+		t.Error("Error of malformed file not returned.")
+	}
+
+	writeRules(t, path, `{"types": {"testProfile": {"Currency": "in:USD"}}}`)
+	if err := rules.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if errs := e.ValidateStruct(profile); errs.JSON() != `{"Currency":["must be in USD"]}` {
+		t.Errorf("Messages of previous file are kept: %s", errs.JSON())
+	}
+
+	if _, err := e.LoadRules(filepath.Join(t.TempDir(), "missing.json"), nil); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Wrong error of missing file: %v", err)
+	}
+}
+
+func TestRuleSet_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{"types": {"testProfile": {"Bio": "max:5", "Biography": "max:5"}, "Profile": {"Name": "required"}}}`)
+
+	e := New()
+	rules, err := e.LoadRules(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var configErr *ConfigError
+	if err := rules.Check(&testProfile{}); !errors.As(err, &configErr) || configErr.Field != "Profile" || !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Wrong error of unknown type: %v", err)
+	}
+
+	writeRules(t, path, `{"types": {"testProfile": {"Bio": "max:5", "Biography": "max:5"}}}`)
+	if err := rules.Reload(); !errors.Is(err, ErrUnknownField) || !strings.Contains(err.Error(), "testProfile.Biography") {
+		t.Errorf("Wrong error of unknown field: %v", err)
+	}
+
+	writeRules(t, path, `{"types": {"validation.testProfile": {"Bio": "max:5"}}}`)
+	if err := rules.Reload(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{"types": {"testProfile": {"Currency": "in:USD"}}, "messages": {"en": {"in": "is not allowed"}}}`)
+
+	rules, err := LoadRules(path, nil)
+	if err != nil || rules != Rules {
+		t.Fatalf("Wrong rule set: %v", err)
+	}
+	defer func() {
+		writeRules(t, path, `{}`)
+		rules.Reload()
+	}()
+
+	if errs := ValidateStruct(testProfile{Currency: "RUB"}); errs.JSON() != `{"Currency":["is not allowed"]}` {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+	if errs := New().ValidateValue("RUB", "in:USD"); errs[0].Error() != "must be in USD" {
+		t.Errorf("Messages of package rules are used by engine: %v", errs)
+	}
+}
+
+func TestRuleSet_Decode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules(t, path, "Bio: max:3")
+
+	// Decoder of flat "Field: rules" lines instead of real YAML
+	decode := func(data []byte, v interface{}) error {
+		fields := map[string]string{}
+		for _, line := range strings.Split(string(data), "\n") {
+			if parts := strings.SplitN(line, ": ", 2); len(parts) == 2 {
+				fields[parts[0]] = parts[1]
+			}
+		}
+		v.(*RuleConfig).Types = map[string]map[string]string{"testProfile": fields}
+		return nil
+	}
+
+	e := New()
+	if _, err := e.LoadRules(path, decode); err != nil {
+		t.Fatal(err)
+	}
+	if errs := e.ValidateStruct(testProfile{Bio: "abcd"}); len(errs["Bio"]) != 1 {
+		t.Errorf("Wrong errors: %s", errs.JSON())
+	}
+}
+
+func TestRuleSet_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{"types": {"testProfile": {"Currency": "in:USD"}}}`)
+
+	e := New()
+	rules, err := e.LoadRules(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var failures int32
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rules.Watch(ctx, time.Millisecond, func(err error) {
+			atomic.AddInt32(&failures, 1)
+		})
+	}()
+
+	writeRules(t, path, `{"types":`)
+	os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	for i := 0; i < 1000 && atomic.LoadInt32(&failures) == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := atomic.LoadInt32(&failures); n != 1 {
+		t.Errorf("Wrong number of reported errors: %d", n)
+	}
+
+	writeRules(t, path, `{"types": {"testProfile": {"Currency": "in:EUR"}}}`)
+	os.Chtimes(path, time.Now().Add(time.Hour), time.Now().Add(time.Hour))
+
+	profile := testProfile{Currency: "EUR"}
+	for i := 0; i < 1000 && !e.ValidateStruct(profile).Empty(); i++ {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	wg.Wait()
+
+	if errs := e.ValidateStruct(profile); !errs.Empty() {
+		t.Errorf("Rules are not reloaded: %s", errs.JSON())
+	}
+}
+
+func TestRuleSet_Overlays(t *testing.T) {
+	type testEvent struct {
+		Title string    `valid:"max:abc"`
+		Date  time.Time `valid:"date:2006-01-02"`
+	}
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{"replace": true, "types": {"testEvent": {"Title": "max:10", "Date": "date:02.01.2006"}}}`)
+
+	e := New()
+	if errs := e.TestStruct(testEvent{}); len(errs) != 1 || errs[0].Field != "Title" {
+		t.Errorf("Wrong errors of struct tags: %v", errs)
+	}
+
+	other := New()
+	other.ValidateStruct(testProfile{})
+	count := atomic.LoadInt64(&other.cache.structs.count)
+
+	if _, err := e.LoadRules(path, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&other.cache.structs.count); n != count || n == 0 {
+		t.Errorf("Cache of another engine is cleared: %d of %d", n, count)
+	}
+
+	if errs := e.TestStruct(testEvent{}); len(errs) != 0 {
+		t.Errorf("Loaded rules are not tested: %v", errs)
+	}
+
+	var event testEvent
+	if errs := e.Cast(map[string]interface{}{"Date": "17.03.2021"}, &event); !errs.Empty() || event.Date.Day() != 17 {
+		t.Errorf("Layout of loaded rules is not used: %s %v", errs.JSON(), event.Date)
+	}
+}
+
+func TestRuleSet_WatchSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{"types": {"testProfile": {"Currency": "in:USD"}}}`)
+	modTime := time.Now().Add(-time.Hour)
+	os.Chtimes(path, modTime, modTime)
+
+	e := New()
+	rules, err := e.LoadRules(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rules.Watch(ctx, time.Millisecond, nil)
+	}()
+
+	writeRules(t, path, `{"types": {"testProfile": {"Currency": "in:USD,EUR"}}}`)
+	os.Chtimes(path, modTime, modTime)
+
+	profile := testProfile{Currency: "EUR"}
+	for i := 0; i < 1000 && !e.ValidateStruct(profile).Empty(); i++ {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if errs := e.ValidateStruct(profile); !errs.Empty() {
+		t.Errorf("Rules are not reloaded by size: %s", errs.JSON())
+	}
+}
@@ -0,0 +1,446 @@
+package validation
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version of JSON Schema what is generated
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON Schema document
+type Schema map[string]interface{}
+
+// Formats of JSON Schema by layouts of "date" rule
+var schemaDateFormats = map[string]string{
+	"2006-01-02":     "date",
+	time.RFC3339:     "date-time",
+	time.RFC3339Nano: "date-time",
+}
+
+// Formats of JSON Schema by rules
+var schemaFormats = map[string]string{
+	"email": "email",
+	"url":   "uri",
+	"ipv4":  "ipv4",
+	"ipv6":  "ipv6",
+}
+
+// Patterns of JSON Schema by rules
+var schemaPatterns = map[string]*regexp.Regexp{
+	"alpha":         regexAlpha,
+	"alpha_numeric": regexAlphaNumeric,
+	"alpha_under":   regexAlphaUnder,
+	"alpha_dash":    regexAlphaDash,
+}
+
+// Builder of schema of struct type
+type schemaBuilder struct {
+	engine   *Engine
+	tags     []string
+	root     reflect.Type
+	visiting map[reflect.Type]bool   // structs what are being built, for recursive types
+	refs     map[reflect.Type]string // recursive types by names of their definitions
+}
+
+// Return JSON Schema of struct by its rules
+// Built-in rules are mapped to keywords, others to extensions like "x-eq_field"
+func JSONSchema(s interface{}, tags ...string) Schema {
+	return defaultEngine().JSONSchema(s, tags...)
+}
+
+// Return JSON Schema of struct by its rules
+func (e *Engine) JSONSchema(s interface{}, tags ...string) Schema {
+	typeOf := reflect.TypeOf(s)
+	for typeOf != nil && typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+	if typeOf == nil || typeOf.Kind() != reflect.Struct {
+		panic(errorWrongType)
+	}
+
+	b := &schemaBuilder{
+		engine:   e,
+		tags:     e.structTags(tags),
+		root:     typeOf,
+		visiting: make(map[reflect.Type]bool),
+		refs:     make(map[reflect.Type]string),
+	}
+	res := b.object(typeOf)
+	if defs := b.definitions(); len(defs) > 0 {
+		res["$defs"] = defs
+	}
+	res["$schema"] = SchemaDialect
+	return res
+}
+
+// Returns JSON representation of schema
+func (s Schema) JSON() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// Return schema of struct
+// Fields are taken from plan of struct like in InspectStruct, so nil embedded pointers are walked too.
+// Fields of embedded structs are properties of the object unless embedded struct is named.
+// Recursive types are referenced by "$ref" to root or to "$defs"
+func (b *schemaBuilder) object(typeOf reflect.Type) Schema {
+	if b.visiting[typeOf] {
+		return Schema{"$ref": b.ref(typeOf)}
+	}
+
+	res := Schema{"type": "object"}
+	b.visiting[typeOf] = true
+	defer delete(b.visiting, typeOf)
+
+	properties := Schema{}
+	var required []string
+	var named [][]int // indexes of embedded structs what are named fields of JSON
+	for _, field := range b.engine.structPlan(typeOf, b.tags) {
+		if promotedBy(field.index, named) {
+			continue
+		}
+		if field.embedded {
+			if !b.named(field.field) {
+				continue // fields of embedded struct are promoted to the object like encoding/json does
+			}
+			named = append(named, field.index)
+		}
+		if !field.field.IsExported() && !field.embedded {
+			continue
+		}
+
+		name, ok := b.engine.externalName(field.field)
+		if !ok {
+			continue
+		}
+		own, dive := splitDive(field.rules)
+		properties[name] = b.value(field.field.Type, own, dive)
+		if hasRule(own, string(Required)) && !hasRule(own, string(Ignore)) {
+			required = append(required, name)
+		}
+	}
+
+	res["properties"] = properties
+	if len(required) > 0 {
+		sort.Strings(required)
+		res["required"] = required
+	}
+	return res
+}
+
+// Check what embedded field is a property of its own
+// Embedded struct is a property if naming strategy gives it a name like tag `json:"base"`, even if its type is unexported
+func (b *schemaBuilder) named(field reflect.StructField) bool {
+	typeOf := field.Type
+	if typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+	if typeOf.Kind() != reflect.Struct {
+		return field.IsExported()
+	}
+	return b.engine.FieldName != nil && len(b.engine.FieldName(field)) > 0
+}
+
+// Check what field is promoted from one of embedded structs
+func promotedBy(index []int, embedded [][]int) bool {
+	for _, prefix := range embedded {
+		if len(index) > len(prefix) && reflect.DeepEqual(index[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Return reference to schema of recursive type
+func (b *schemaBuilder) ref(typeOf reflect.Type) string {
+	if typeOf == b.root {
+		return "#"
+	}
+	name := typeOf.String()
+	b.refs[typeOf] = name
+	return "#/$defs/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// Return definitions of referenced recursive types
+// Types referenced by definitions are added too
+func (b *schemaBuilder) definitions() Schema {
+	defs := Schema{}
+	for len(defs) < len(b.refs) {
+		for typeOf, name := range b.refs {
+			if _, ok := defs[name]; !ok {
+				defs[name] = b.object(typeOf)
+			}
+		}
+	}
+	return defs
+}
+
+// Return schema of value by its type and rules
+func (b *schemaBuilder) value(typeOf reflect.Type, rules []interface{}, dive Dive) Schema {
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	res := Schema{}
+	switch {
+	case typeOf == timeType:
+		res["type"] = "string"
+		res["format"] = "date-time"
+	case typeOf.Kind() == reflect.String:
+		res["type"] = "string"
+	case typeOf.Kind() == reflect.Bool:
+		res["type"] = "boolean"
+	case typeOf.Kind() == reflect.Float32 || typeOf.Kind() == reflect.Float64:
+		res["type"] = "number"
+	case typeOf.Kind() >= reflect.Uint && typeOf.Kind() <= reflect.Uintptr:
+		res["type"] = "integer"
+		res["minimum"] = 0
+	case isNumber(reflect.New(typeOf).Elem()):
+		res["type"] = "integer"
+	case typeOf.Kind() == reflect.Slice && typeOf.Elem().Kind() == reflect.Uint8:
+		res["type"] = "string"
+		res["contentEncoding"] = "base64"
+	case typeOf.Kind() == reflect.Slice || typeOf.Kind() == reflect.Array:
+		res["type"] = "array"
+		own, nested := splitDive(dive.Values...)
+		res["items"] = b.value(typeOf.Elem(), own, nested)
+	case typeOf.Kind() == reflect.Map:
+		res["type"] = "object"
+		own, nested := splitDive(dive.Values...)
+		res["additionalProperties"] = b.value(typeOf.Elem(), own, nested)
+		if len(dive.Keys) > 0 {
+			res["propertyNames"] = b.value(typeOf.Key(), dive.Keys, Dive{})
+		}
+	case typeOf.Kind() == reflect.Struct:
+		res = b.object(typeOf)
+	}
+
+	if hasRule(rules, string(Ignore)) {
+		return res
+	}
+
+	numeric := hasRule(rules, string(Numeric))
+	for _, arg := range rules {
+		if rule, ok := arg.(Rule); ok {
+			b.rule(res, typeOf, rule, numeric)
+		}
+	}
+	return res
+}
+
+// Add keywords of rule to schema
+func (b *schemaBuilder) rule(res Schema, typeOf reflect.Type, rule Rule, numeric bool) {
+	kind := res["type"]
+	params := rule.Params
+
+	if format, ok := schemaFormats[rule.Name]; ok && kind == "string" {
+		res["format"] = format
+		return
+	}
+	if pattern, ok := schemaPatterns[rule.Name]; ok && kind == "string" {
+		res["pattern"] = pattern.String()
+		return
+	}
+
+	switch {
+	case rule.Name == string(Required) || rule.Name == string(Lazy):
+		return
+	case rule.Name == string(Numeric) && kind == "string":
+		res["pattern"] = regexDecimal.String()
+		return
+	case b.isAction(rule.Name):
+		return
+	}
+
+	switch rule.Name {
+	case "min", "max", "len", "gt", "lt":
+		if numeric || len(params) != 1 {
+			break
+		}
+		if n, ok := schemaNumber(params[0]); ok && b.sizeRule(res, rule.Name, n) {
+			return
+		}
+
+	case "min_value", "max_value":
+		if (kind != "integer" && kind != "number") || len(params) != 1 {
+			break
+		}
+		if n, ok := schemaNumber(params[0]); ok {
+			res[map[string]string{"min_value": "minimum", "max_value": "maximum"}[rule.Name]] = n
+			return
+		}
+
+	case "between":
+		if (kind != "integer" && kind != "number") || len(params) != 2 {
+			break
+		}
+		min, ok1 := schemaNumber(params[0])
+		max, ok2 := schemaNumber(params[1])
+		if ok1 && ok2 {
+			res["minimum"] = min
+			res["maximum"] = max
+			return
+		}
+
+	case "in", "not_in":
+		enum := schemaEnum(typeOf, params)
+		if enum == nil {
+			break
+		}
+		if rule.Name == "in" {
+			res["enum"] = enum
+		} else {
+			res["not"] = Schema{"enum": enum}
+		}
+		return
+
+	case "regex":
+		if len(params) > 0 && kind == "string" {
+			if s, ok := params[0].(string); ok {
+				res["pattern"] = s
+				return
+			}
+		}
+
+	case "has_prefix", "has_suffix", "contains":
+		if len(params) > 0 && kind == "string" {
+			s := regexp.QuoteMeta(params[0].(string))
+			res["pattern"] = map[string]string{"has_prefix": "^" + s, "has_suffix": s + "$", "contains": s}[rule.Name]
+			return
+		}
+
+	case "date":
+		if len(params) > 0 && kind == "string" {
+			if format, ok := schemaDateFormats[params[0].(string)]; ok {
+				res["format"] = format
+				return
+			}
+		}
+
+	case "json":
+		if kind == "string" {
+			res["contentMediaType"] = "application/json"
+			return
+		}
+	}
+
+	res["x-"+rule.Name] = schemaExtension(params)
+}
+
+// Add keywords of size rule to schema
+// It returns false if type has no size keywords
+func (b *schemaBuilder) sizeRule(res Schema, name string, n json.Number) bool {
+	var min, max string
+	switch res["type"] {
+	case "string":
+		min, max = "minLength", "maxLength"
+	case "array":
+		min, max = "minItems", "maxItems"
+	case "object":
+		min, max = "minProperties", "maxProperties"
+	case "integer", "number":
+		switch name {
+		case "min":
+			res["minimum"] = n
+		case "max":
+			res["maximum"] = n
+		case "gt":
+			res["exclusiveMinimum"] = n
+		case "lt":
+			res["exclusiveMaximum"] = n
+		default:
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+
+	size, err := n.Int64()
+	if err != nil {
+		return false
+	}
+	switch name {
+	case "min":
+		res[min] = size
+	case "max":
+		res[max] = size
+	case "len":
+		res[min], res[max] = size, size
+	case "gt":
+		res[min] = size + 1
+	case "lt":
+		if size < 1 {
+			return false
+		}
+		res[max] = size - 1
+	}
+	return true
+}
+
+// Check what rule is an action of engine
+func (b *schemaBuilder) isAction(name string) bool {
+	if _, ok := b.engine.validator(name); ok {
+		return false
+	}
+	_, ok := b.engine.action(name)
+	return ok
+}
+
+// Check what rules have rule with name
+func hasRule(rules []interface{}, name string) bool {
+	for _, arg := range rules {
+		if rule, ok := arg.(Rule); ok && rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Return number of parameter with its original text
+func schemaNumber(param interface{}) (json.Number, bool) {
+	s, ok := param.(string)
+	if !ok {
+		return "", false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return "", false
+	}
+	return json.Number(s), true
+}
+
+// Return values of enum converted to type of field
+// It returns nil if parameters are not values of type
+func schemaEnum(typeOf reflect.Type, params []interface{}) []interface{} {
+	res := make([]interface{}, len(params))
+	for i, param := range params {
+		if !isNumber(reflect.New(typeOf).Elem()) {
+			if typeOf.Kind() != reflect.String {
+				return nil
+			}
+			res[i] = param
+			continue
+		}
+
+		n, ok := schemaNumber(param)
+		if !ok {
+			return nil
+		}
+		res[i] = n
+	}
+	return res
+}
+
+// Return value of extension of rule, it is true if rule has no parameters
+func schemaExtension(params []interface{}) interface{} {
+	if len(params) == 0 {
+		return true
+	}
+	return params
+}
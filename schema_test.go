@@ -0,0 +1,175 @@
+package validation
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testSchemaAddress struct {
+	City string `valid:"required|trim|max:50"`
+	Zip  string `valid:"regex:^\\d{5}$"`
+}
+
+type testSchemaOrder struct {
+	Email    string            `valid:"required|email"`
+	Name     string            `valid:"min:2|max:100|alpha"`
+	Age      int               `valid:"min:18|lt:130"`
+	Count    uint              `valid:"between:1,10"`
+	Status   string            `valid:"in:new,paid"`
+	Priority int               `valid:"in:1,2,3"`
+	Code     string            `valid:"has_prefix:A.|len:5"`
+	Amount   string            `valid:"numeric|min:1"`
+	Born     string            `valid:"date:2006-01-02|date_lte:2006-01-02,today"`
+	Created  time.Time         `valid:"required"`
+	Tags     []string          `valid:"max:5|dive|min:2"`
+	Labels   map[string]int    `valid:"dive|keys|alpha|endkeys|gt:0"`
+	Phone    string            `valid:"required_without:Email|test_schema_phone:7"`
+	Password string            `on_create:"required|password"`
+	Address  testSchemaAddress `valid:"required"`
+	Parent   *testSchemaOrder
+	Secret   string `json:"-"`
+	internal string `valid:"required"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	Validators.Add("test_schema_phone", func(v interface{}, options OptionList, params ...interface{}) error {
+		return nil
+	})
+
+	var r map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["Address", "Created", "Email"],
+		"properties": {
+			"Email": {"type": "string", "format": "email"},
+			"Name": {"type": "string", "minLength": 2, "maxLength": 100, "pattern": "^[a-zA-Z]+$"},
+			"Age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 130},
+			"Count": {"type": "integer", "minimum": 1, "maximum": 10},
+			"Status": {"type": "string", "enum": ["new", "paid"]},
+			"Priority": {"type": "integer", "enum": [1, 2, 3]},
+			"Code": {"type": "string", "pattern": "^A\\.", "minLength": 5, "maxLength": 5},
+			"Amount": {"type": "string", "pattern": "^[-+]?([0-9]+(\\.[0-9]*)?|\\.[0-9]+)([eE][-+]?[0-9]{1,4})?$", "x-min": ["1"]},
+			"Born": {"type": "string", "format": "date", "x-date_lte": ["2006-01-02", "today"]},
+			"Created": {"type": "string", "format": "date-time"},
+			"Tags": {"type": "array", "maxItems": 5, "items": {"type": "string", "minLength": 2}},
+			"Labels": {"type": "object", "propertyNames": {"type": "string", "pattern": "^[a-zA-Z]+$"}, "additionalProperties": {"type": "integer", "exclusiveMinimum": 0}},
+			"Phone": {"type": "string", "x-required_without": ["Email"], "x-test_schema_phone": ["7"]},
+			"Password": {"type": "string"},
+			"Address": {"type": "object", "required": ["City"], "properties": {
+				"City": {"type": "string", "maxLength": 50},
+				"Zip": {"type": "string", "pattern": "^\\d{5}$"}
+			}},
+			"Parent": {"$ref": "#"},
+			"Secret": {"type": "string"}
+		}
+	}`), &r)
+
+	var res map[string]interface{}
+	json.Unmarshal([]byte(JSONSchema(testSchemaOrder{}).JSON()), &res)
+	if !reflect.DeepEqual(res, r) {
+		t.Errorf("Wrong schema: %s", JSONSchema(testSchemaOrder{}).JSON())
+	}
+
+	res = JSONSchema(&testSchemaOrder{}, "valid", "on_create")
+	password := res["properties"].(Schema)["Password"].(Schema)
+	if password["x-password"] != true || len(res["required"].([]string)) != 4 {
+		t.Errorf("Wrong schema of scenario: %v %v", password, res["required"])
+	}
+}
+
+func TestJSONSchema_FieldName(t *testing.T) {
+	e := New()
+	e.FieldName = TagName("json")
+
+	type login struct {
+		Email string `json:"email" valid:"required"`
+		Name  string `json:"-"`
+	}
+
+	res := e.JSONSchema(login{})
+	properties := res["properties"].(Schema)
+	if _, ok := properties["email"]; !ok || len(properties) != 1 || res["required"].([]string)[0] != "email" {
+		t.Errorf("Wrong schema: %s", res.JSON())
+	}
+}
+
+type testSchemaNode struct {
+	Name     string `valid:"required"`
+	Children []testSchemaNode
+	Parent   *testSchemaNode
+	Tag      *testSchemaTag
+}
+
+type testSchemaTag struct {
+	Label string
+	Tags  []*testSchemaTag
+}
+
+func TestJSONSchema_Recursive(t *testing.T) {
+	res := JSONSchema(testSchemaNode{})
+	properties := res["properties"].(Schema)
+
+	if properties["Parent"].(Schema)["$ref"] != "#" || properties["Children"].(Schema)["items"].(Schema)["$ref"] != "#" {
+		t.Errorf("Wrong references to root: %s", res.JSON())
+	}
+
+	tag := properties["Tag"].(Schema)
+	ref := tag["properties"].(Schema)["Tags"].(Schema)["items"].(Schema)["$ref"]
+	if ref != "#/$defs/validation.testSchemaTag" {
+		t.Errorf("Wrong reference to definition: %s", res.JSON())
+	}
+
+	def := res["$defs"].(Schema)["validation.testSchemaTag"].(Schema)
+	if def["type"] != "object" || def["properties"].(Schema)["Tags"].(Schema)["items"].(Schema)["$ref"] != ref {
+		t.Errorf("Wrong definition: %s", res.JSON())
+	}
+}
+
+type testSchemaBase struct {
+	ID      int `json:"id" valid:"required"`
+	Created time.Time
+}
+
+type testSchemaMeta struct {
+	Note string `json:"note" valid:"max:10"`
+}
+
+type testSchemaDocument struct {
+	testSchemaBase  `valid:"required"`
+	*testSchemaMeta `json:"meta"`
+	Title           string `json:"title" valid:"required"`
+}
+
+func TestJSONSchema_Embedded(t *testing.T) {
+	e := New()
+	e.FieldName = TagName("json")
+
+	var r map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["id", "title"],
+		"properties": {
+			"id": {"type": "integer"},
+			"Created": {"type": "string", "format": "date-time"},
+			"meta": {"type": "object", "properties": {"note": {"type": "string", "maxLength": 10}}},
+			"title": {"type": "string"}
+		}
+	}`), &r)
+
+	var res map[string]interface{}
+	json.Unmarshal([]byte(e.JSONSchema(testSchemaDocument{}).JSON()), &res)
+	if !reflect.DeepEqual(res, r) {
+		t.Errorf("Wrong schema: %s", e.JSONSchema(testSchemaDocument{}).JSON())
+	}
+
+	properties := JSONSchema(testSchemaDocument{})["properties"].(Schema)
+	for _, name := range []string{"ID", "Created", "Note", "Title"} {
+		if _, ok := properties[name]; !ok || len(properties) != 4 {
+			t.Errorf("Fields of embedded structs are not promoted: %v", properties)
+		}
+	}
+}
@@ -1,11 +1,278 @@
 package validation
 
-// функции для тестирования правил валидации
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+)
 
-func TestStruct(s interface{}) bool {
-	return false
+// Error of validation rules syntax
+type SyntaxError struct {
+	Field   string
+	Tag     string
+	Rule    string
+	Message string
 }
 
-func TestSyntax(s string) bool {
-	return false
+// Parameters specification of build-in rule
+type paramsSpec struct {
+	Min     int
+	Max     int // -1 for unlimited
+	Numeric bool
+	Check   func(params []interface{}) error
+}
+
+// Parameters specifications of build-in rules
+// Rules what are not listed have no parameters
+var rulesParams = map[string]paramsSpec{
+	"min":           {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"max":           {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"len":           {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"gt":            {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"min_value":     {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"max_value":     {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"between":       {Min: 2, Max: 2, Numeric: true, Check: numberParams},
+	"lt":            {Min: 1, Max: 1, Numeric: true, Check: numberParams},
+	"in":            {Min: 1, Max: -1},
+	"not_in":        {Min: 1, Max: -1},
+	"has_keys":      {Min: 1, Max: -1},
+	"has_only_keys": {Min: 1, Max: -1},
+	"contains":      {Min: 1, Max: 1},
+	"has_prefix":    {Min: 1, Max: 1},
+	"has_suffix":    {Min: 1, Max: 1},
+	"date":          {Min: 1, Max: 1},
+	"regex":         {Min: 1, Max: 1, Check: regexParams},
+	"date_gte":      {Min: 2, Max: 3, Check: dateParams},
+	"date_lte":      {Min: 2, Max: 3, Check: dateParams},
+	"date_gt":       {Min: 2, Max: 3, Check: dateParams},
+	"date_lt":       {Min: 2, Max: 3, Check: dateParams},
+	"eq_field":      {Min: 1, Max: 2},
+	"ne_field":      {Min: 1, Max: 2},
+	"gt_field":      {Min: 1, Max: 2},
+	"gte_field":     {Min: 1, Max: 2},
+	"lt_field":      {Min: 1, Max: 2},
+	"lte_field":     {Min: 1, Max: 2},
+
+	"required_if":          {Min: 2, Max: -1},
+	"required_unless":      {Min: 2, Max: -1},
+	"required_with":        {Min: 1, Max: -1},
+	"required_with_all":    {Min: 1, Max: -1},
+	"required_without":     {Min: 1, Max: -1},
+	"required_without_all": {Min: 1, Max: -1},
+	"excluded_if":          {Min: 2, Max: -1},
+	"excluded_unless":      {Min: 2, Max: -1},
+	"excluded_with":        {Min: 1, Max: -1},
+	"excluded_without":     {Min: 1, Max: -1},
+}
+
+// Returns description of syntax error
+func (e SyntaxError) Error() string {
+	var res string
+	if len(e.Field) > 0 {
+		res = fmt.Sprintf("%s (tag \"%s\"): ", e.Field, e.Tag)
+	}
+	if len(e.Rule) > 0 {
+		res += fmt.Sprintf("rule \"%s\": ", e.Rule)
+	}
+	return res + e.Message
+}
+
+// Check rules of every tag of every field of struct type
+// Nested structs and structs in slices, arrays and maps are checked recursively
+// If tags are not specified default tag is used
+func TestStruct(s interface{}, tags ...string) []SyntaxError {
+	return defaultEngine().TestStruct(s, tags...)
+}
+
+// Check rules string
+// It reports unknown rules, wrong parameters count and unparseable parameters
+func TestSyntax(s string) []SyntaxError {
+	return defaultEngine().TestSyntax(s)
+}
+
+// Check rules of struct type by registries of engine
+func (e *Engine) TestStruct(s interface{}, tags ...string) []SyntaxError {
+	tags = e.structTags(tags)
+
+	typeOf, ok := s.(reflect.Type)
+	if !ok {
+		typeOf = reflect.TypeOf(s)
+	}
+
+	return e.testStruct(typeOf, "", tags, map[reflect.Type]bool{})
+}
+
+// Check rules string by registries of engine
+func (e *Engine) TestSyntax(s string) []SyntaxError {
+	rules, err := ParseRules(s)
+	if err != nil {
+		parseErr := err.(*ParseError)
+		return []SyntaxError{{Message: fmt.Sprintf("column %d: %s", parseErr.Column, parseErr.Message)}}
+	}
+	return e.testRules(rules)
+}
+
+func (e *Engine) testStruct(typeOf reflect.Type, path string, tags []string, visited map[reflect.Type]bool) (res []SyntaxError) {
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	if typeOf.Kind() != reflect.Struct {
+		return []SyntaxError{{Field: path, Message: errorWrongType}}
+	}
+
+	if visited[typeOf] {
+		return nil
+	}
+	visited[typeOf] = true
+
+	rules := e.Rules.current()
+	for _, field := range reflect.VisibleFields(typeOf) {
+		fieldPath := joinPath(path, field.Name)
+
+		for _, tag := range tags {
+			for _, err := range e.TestSyntax(rules.rules(tag, typeOf, field.Name, field.Tag.Get(tag))) {
+				err.Field = fieldPath
+				err.Tag = tag
+				res = append(res, err)
+			}
+		}
+
+		// fields of embedded struct are already promoted
+		if field.Anonymous {
+			continue
+		}
+
+		if nested, ok := nestedType(field.Type); ok {
+			res = append(res, e.testStruct(nested, fieldPath, tags, visited)...)
+		}
+	}
+
+	return res
+}
+
+// Return struct type of field, elements of slices, arrays and maps
+func nestedType(t reflect.Type) (reflect.Type, bool) {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t, t != timeType
+		default:
+			return nil, false
+		}
+	}
+}
+
+func (e *Engine) testRules(rules []Rule) (res []SyntaxError) {
+	var inKeys bool
+
+	for i, rule := range rules {
+		switch rule.Name {
+		case diveRule:
+		case keysRule:
+			if i == 0 || rules[i-1].Name != diveRule {
+				res = append(res, SyntaxError{Rule: rule.Name, Message: "must follow \"dive\""})
+				continue
+			}
+			inKeys = true
+		case endKeysRule:
+			if !inKeys {
+				res = append(res, SyntaxError{Rule: rule.Name, Message: "must close \"keys\""})
+			}
+			inKeys = false
+		default:
+			if !e.exists(rule) {
+				res = append(res, SyntaxError{Rule: rule.Name, Message: "rule not found"})
+				continue
+			}
+			if err := e.testParams(rule); err != nil {
+				res = append(res, SyntaxError{Rule: rule.Name, Message: err.Error()})
+			}
+		}
+	}
+
+	if inKeys {
+		res = append(res, SyntaxError{Rule: keysRule, Message: "must be closed by \"endkeys\""})
+	}
+
+	return res
+}
+
+// Check parameters of build-in rule
+// Parameters of custom validators are not checked
+func (e *Engine) testParams(rule Rule) error {
+	spec, ok := rulesParams[rule.Name]
+	if !ok {
+		if rule.IsBuiltin() || rule.IsCrossField() || e.isOption(rule) || e.isAction(rule) {
+			spec = paramsSpec{}
+		} else {
+			return nil
+		}
+	}
+
+	count := len(rule.Params)
+	switch {
+	case spec.Min == spec.Max && count != spec.Min:
+		return fmt.Errorf("expects %d parameters, got %d", spec.Min, count)
+	case count < spec.Min:
+		return fmt.Errorf("expects at least %d parameters, got %d", spec.Min, count)
+	case spec.Max >= 0 && count > spec.Max:
+		return fmt.Errorf("expects at most %d parameters, got %d", spec.Max, count)
+	}
+
+	if spec.Check != nil {
+		return spec.Check(rule.Params)
+	}
+	return nil
+}
+
+func (e *Engine) isOption(rule Rule) bool {
+	_, ok := e.option(rule.Name)
+	return ok
+}
+
+func (e *Engine) isAction(rule Rule) bool {
+	_, ok := e.action(rule.Name)
+	return ok
+}
+
+// All parameters must be numbers
+func numberParams(params []interface{}) error {
+	for _, param := range params {
+		if _, err := parseFloat(param); err != nil {
+			return fmt.Errorf("parameter \"%v\" must be a number", param)
+		}
+	}
+	return nil
+}
+
+// Parameter must be a valid regular expression
+func regexParams(params []interface{}) error {
+	if _, err := regexp.Compile(parseString(params[0])); err != nil {
+		return fmt.Errorf("parameter \"%v\" must be a valid regular expression: %s", params[0], err)
+	}
+	return nil
+}
+
+// Second parameter must be a date placeholder or a date in layout of first parameter
+func dateParams(params []interface{}) error {
+	layout := parseString(params[0])
+	date := parseString(params[1])
+
+	if len(params) > 2 {
+		if _, err := loadLocation(parseString(params[2])); err != nil {
+			return fmt.Errorf("parameter \"%s\" must be a time zone", parseString(params[2]))
+		}
+	}
+
+	if _, err := GetDate(DatePlaceholder(date)); err == nil {
+		return nil
+	}
+	if _, err := time.Parse(layout, date); err != nil {
+		return fmt.Errorf("parameter \"%s\" must be a date placeholder or a date in \"%s\" layout", date, layout)
+	}
+	return nil
 }
@@ -0,0 +1,62 @@
+package validation
+
+import "testing"
+
+func TestTestSyntax(t *testing.T) {
+	var items = []struct {
+		Rules  string
+		Errors int
+	}{
+		{Rules: "required|max:255|in:x,y,z|trim", Errors: 0},
+		{Rules: "date_gte:02-01-2006,-18Y|date_lt:02-01-2006,01-01-2020", Errors: 0},
+		{Rules: "max:10|dive|keys|alpha|endkeys|email", Errors: 0},
+		{Rules: "max:abc", Errors: 1},
+		{Rules: "max", Errors: 1},
+		{Rules: "email:x", Errors: 1},
+		{Rules: "fake|REQUIRED", Errors: 2},
+		{Rules: "date_gte:2006-01-02", Errors: 1},
+		{Rules: "date_gte:2006-01-02,yesterday1", Errors: 1},
+		{Rules: "keys|alpha", Errors: 1},
+		{Rules: "dive|keys|alpha", Errors: 1},
+		{Rules: "dive|endkeys", Errors: 1},
+		{Rules: "regex:^[a-z]{1,3}$|regex:'^(a|b)$'", Errors: 0},
+		{Rules: "regex:(", Errors: 1},
+		{Rules: "in:'a,b", Errors: 1},
+		{Rules: "time:15:04,|trim:x|lazy:1|required_if:", Errors: 4},
+		{Rules: "date:2006-01-02,", Errors: 1},
+		{Rules: `regex:^\\b\d$`, Errors: 0},
+	}
+
+	for _, item := range items {
+		if errs := TestSyntax(item.Rules); len(errs) != item.Errors {
+			t.Errorf("Expected %d errors for «%s», got %v.", item.Errors, item.Rules, errs)
+		}
+	}
+}
+
+type testSyntaxItem struct {
+	Name string `valid:"required|min:x"`
+}
+
+type testSyntaxStruct struct {
+	Name  string `valid:"required|max:255" on_create:"fake"`
+	Date  string `valid:"date_gte:2006-01-02"`
+	Items []testSyntaxItem
+}
+
+func TestTestStruct(t *testing.T) {
+	errs := TestStruct(testSyntaxStruct{}, "valid", "on_create")
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v.", errs)
+	}
+
+	if errs[0].Field != "Name" || errs[0].Tag != "on_create" || errs[0].Rule != "fake" {
+		t.Errorf("Wrong error %+v.", errs[0])
+	}
+	if errs[2].Field != "Items.Name" || errs[2].Rule != "min" {
+		t.Errorf("Wrong error %+v.", errs[2])
+	}
+	if errs[2].Error() != "Items.Name (tag \"valid\"): rule \"min\": parameter \"x\" must be a number" {
+		t.Error(errs[2].Error())
+	}
+}
@@ -1,8 +1,10 @@
 package validation
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -11,57 +13,258 @@ import (
 )
 
 const (
-	Now       DatePlaceholder = "now"
-	Today     DatePlaceholder = "today"
-	Yesterday DatePlaceholder = "yesterday"
-	Tomorrow  DatePlaceholder = "tomorrow"
+	Now          DatePlaceholder = "now"
+	Today        DatePlaceholder = "today"
+	Yesterday    DatePlaceholder = "yesterday"
+	Tomorrow     DatePlaceholder = "tomorrow"
+	StartOfWeek  DatePlaceholder = "start_of_week"
+	EndOfWeek    DatePlaceholder = "end_of_week"
+	StartOfMonth DatePlaceholder = "start_of_month"
+	EndOfMonth   DatePlaceholder = "end_of_month"
+	StartOfYear  DatePlaceholder = "start_of_year"
+	EndOfYear    DatePlaceholder = "end_of_year"
 )
 
+// Function what returns current time for date placeholders
+type ClockFunc func() time.Time
+
+// Clock of package functions, time.Now is used if it is nil
+var Clock ClockFunc
+
+// Key of clock in context
+type clockKey struct{}
+
 var (
-	regexDateModifier = regexp.MustCompile("([-|+]?[\\d]+)([Y|M|D|h|m|s])")
+	timeType = reflect.TypeOf(time.Time{})
+
+	regexDecimal = regexp.MustCompile("^[-+]?([0-9]+(\\.[0-9]*)?|\\.[0-9]+)([eE][-+]?[0-9]{1,4})?$")
+
+	regexDateModifier  = regexp.MustCompile("([-+]?\\d+)([YMWDhms])")
+	regexDateModifiers = regexp.MustCompile("^([-+]?\\d+[YMWDhms])+$")
 )
 
+// Return context with clock what is used by date rules instead of clock of engine
+func WithClock(ctx context.Context, clock ClockFunc) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// Return clock of context or time.Now
+func clockOf(ctx context.Context) ClockFunc {
+	if clock, ok := ctx.Value(clockKey{}).(ClockFunc); ok && clock != nil {
+		return clock
+	}
+	return time.Now
+}
+
 // Return date and time modified by placeholder
 // Placeholders list:
 // now - current date and current time
 // today - current date and 00:00:00:00 time
 // yesterday - current date - 1 day and 00:00:00:00 time
-// +-{n}Y - add or remove n years to current date and current time
-// +-{n}M - add or remove n months to current date and current time
+// tomorrow - current date + 1 day and 00:00:00:00 time
+// start_of_week, start_of_month, start_of_year - first day of period and 00:00:00:00 time, weeks start on monday
+// end_of_week, end_of_month, end_of_year - last day of period and 23:59:59.999999999 time
+// +-{n}Y - add or remove n years to current date and current time, 29 February becomes 28 February
+// +-{n}M - add or remove n months to current date and current time, day is limited by the last day of month
+// +-{n}W - add or remove n weeks to current date and current time
 // +-{n}D - add or remove n days to current date and current time
 // +-{n}h - add or remove n hours to current date and current time
 // +-{n}m - add or remove n minutes to current date and current time
 // +-{n}s - add or remove n seconds to current date and current time
 //
-// You can combine Y,M,D,h,m,s modifiers.
+// You can combine Y,M,W,D,h,m,s modifiers and put them after a placeholder of day.
 // Example: get a date that was 18 years and 3 days ago, plus 22 seconds:
 // dt, err := GetDate("-18Y -3h +22s")
+// Example: get the first day of previous month:
+// dt, err := GetDate("start_of_month -1M")
 func GetDate(placeholder DatePlaceholder) (time.Time, error) {
-	now := time.Now()
+	return GetDateAt(placeholder, time.Now())
+}
+
+// Return date modified by placeholder relative to specified current time
+// Days and periods are taken in time zone of current time
+func GetDateAt(placeholder DatePlaceholder, now time.Time) (time.Time, error) {
+	tokens := strings.Fields(string(placeholder))
+	if len(tokens) == 0 {
+		return time.Time{}, errors.New("placeholder not exists")
+	}
+
+	base := DatePlaceholder(tokens[0])
+	date, ok := baseDate(base, now)
+	if ok {
+		tokens = tokens[1:]
+	} else {
+		base, date = Now, now
+	}
+
+	if len(tokens) == 0 {
+		return date, nil
+	}
+
+	modifiers, err := parseDateModifiers(strings.Join(tokens, " "))
+	if err != nil {
+		return time.Time{}, errors.New("placeholder not exists")
+	}
+
+	if modifiers["Y"] != 0 || modifiers["M"] != 0 {
+		date = shiftDate(base, date, now, modifiers["Y"], modifiers["M"])
+	}
+	date = date.AddDate(0, 0, modifiers["D"]+modifiers["W"]*7)
+	h := time.Hour * time.Duration(modifiers["h"])
+	m := time.Minute * time.Duration(modifiers["m"])
+	s := time.Second * time.Duration(modifiers["s"])
+	return date.Add(h + m + s), nil
+}
+
+// Return date of placeholder without modifiers
+func baseDate(placeholder DatePlaceholder, now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
 
 	switch placeholder {
 	case Now:
-		return now, nil
+		return now, true
 	case Today:
-		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+		return today, true
 	case Yesterday:
-		y := now.AddDate(0, 0, -1)
-		return time.Date(y.Year(), y.Month(), y.Day(), 0, 0, 0, 0, y.Location()), nil
+		return today.AddDate(0, 0, -1), true
 	case Tomorrow:
-		t := now.AddDate(0, 0, 1)
-		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
-	default:
-		modifiers, err := parseDateModifiers(string(placeholder))
-		if err == nil {
-			date := now.AddDate(modifiers["Y"], modifiers["M"], modifiers["D"])
-			h := time.Hour * time.Duration(modifiers["h"])
-			m := time.Minute * time.Duration(modifiers["m"])
-			s := time.Second * time.Duration(modifiers["s"])
-			return date.Add(h + m + s), nil
+		return today.AddDate(0, 0, 1), true
+	case StartOfWeek:
+		return weekStart, true
+	case EndOfWeek:
+		return weekStart.AddDate(0, 0, 7).Add(-time.Nanosecond), true
+	case StartOfMonth:
+		return monthStart, true
+	case EndOfMonth:
+		return monthStart.AddDate(0, 1, 0).Add(-time.Nanosecond), true
+	case StartOfYear:
+		return yearStart, true
+	case EndOfYear:
+		return yearStart.AddDate(1, 0, 0).Add(-time.Nanosecond), true
+	}
+	return time.Time{}, false
+}
+
+// Placeholders of periods by placeholders of their start
+var periodStarts = map[DatePlaceholder]DatePlaceholder{
+	StartOfWeek:  StartOfWeek,
+	EndOfWeek:    StartOfWeek,
+	StartOfMonth: StartOfMonth,
+	EndOfMonth:   StartOfMonth,
+	StartOfYear:  StartOfYear,
+	EndOfYear:    StartOfYear,
+}
+
+// Add years and months to date of placeholder
+// Start of period is shifted and bound of period is taken again, so "end_of_month -1M" is the last day
+// of previous month. Day of other dates is limited by the last day of target month
+func shiftDate(placeholder DatePlaceholder, date time.Time, now time.Time, years int, months int) time.Time {
+	start, ok := periodStarts[placeholder]
+	if !ok {
+		return addMonths(date, years, months)
+	}
+
+	startDate, _ := baseDate(start, now)
+	res, _ := baseDate(placeholder, addMonths(startDate, years, months))
+	return res
+}
+
+// Add years and months to date, day is limited by the last day of target month
+func addMonths(date time.Time, years int, months int) time.Time {
+	year, month, day := date.Date()
+	if last := time.Date(year+years, month+time.Month(months)+1, 0, 0, 0, 0, 0, date.Location()).Day(); day > last {
+		day = last
+	}
+	return time.Date(year+years, month+time.Month(months), day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
+
+// Return exact value of decimal number in string, nil if string is not a number
+func parseDecimal(s string) *big.Rat {
+	if !regexDecimal.MatchString(s) {
+		return nil
+	}
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil
+	}
+	return rat
+}
+
+// Return exact value of number or numeric string
+func ratOf(value reflect.Value) (*big.Rat, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		rat := parseDecimal(value.String())
+		return rat, rat != nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(value.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		rat := new(big.Rat).SetFloat64(value.Float())
+		return rat, rat != nil
+	}
+	return nil, false
+}
+
+// Return exact value of numeric parameter
+// It panics if parameter is not a number
+func paramRat(param interface{}) *big.Rat {
+	if n, ok := param.(number); ok {
+		if n.rat != nil {
+			return n.rat
+		}
+		param = n.text
+	}
+
+	rat, ok := ratOf(reflect.ValueOf(param))
+	if !ok {
+		panic(fmt.Errorf("parameter %v must be a number", param))
+	}
+	return rat
+}
+
+// Return time of time.Time, pointer to it or struct like sql.NullTime with Time and Valid fields
+// It returns null if value is nil pointer or it is not valid
+func timeOf(value reflect.Value) (t time.Time, null bool, ok bool) {
+	if !value.IsValid() {
+		return t, false, false
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			elem := value.Type().Elem()
+			return t, true, elem == timeType || isNullTime(elem)
 		}
+		value = value.Elem()
 	}
 
-	return time.Time{}, errors.New("placeholder not exists")
+	switch {
+	case value.Type() == timeType:
+		return value.Interface().(time.Time), false, true
+	case isNullTime(value.Type()):
+		if !value.FieldByName("Valid").Bool() {
+			return t, true, true
+		}
+		return value.FieldByName("Time").Interface().(time.Time), false, true
+	}
+	return t, false, false
+}
+
+// Check what type is struct like sql.NullTime
+func isNullTime(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	timeField, ok := t.FieldByName("Time")
+	if !ok || timeField.Type != timeType {
+		return false
+	}
+	validField, ok := t.FieldByName("Valid")
+	return ok && validField.Type.Kind() == reflect.Bool
 }
 
 // Return length or value converted to float
@@ -83,13 +286,16 @@ func size(value reflect.Value) float64 {
 }
 
 // Return new string with replaced parameters by their index
+// Token {*} is replaced by all parameters separated by comma
 func replace(message string, params []interface{}) string {
+	all := make([]string, len(params))
 	for i, item := range params {
 		token := fmt.Sprintf("{%d}", i)
 		value := fmt.Sprintf("%v", item)
 		message = strings.Replace(message, token, value, -1)
+		all[i] = value
 	}
-	return message
+	return strings.Replace(message, "{*}", strings.Join(all, ","), -1)
 }
 
 // Return weather that string in slice
@@ -104,16 +310,34 @@ func in(value string, items []string) bool {
 
 // Return validation error with replaced parameters
 func errorMessage(ruleName string, params ...interface{}) error {
-	message := Messages[ruleName]
-	if len(message) == 0 {
+	message := renderMessage(Locale, ruleName, params)
+	return &FieldError{Rule: ruleName, Params: params, Message: message}
+}
+
+// Return message of rule in locale with replaced parameters
+func renderMessage(locale string, ruleName string, params []interface{}) string {
+	return Catalogs.render(locale, ruleName, params)
+}
+
+// Return message of rule from catalogs with replaced parameters
+func (c *CatalogMap) render(locale string, ruleName string, params []interface{}) string {
+	message, ok := c.message(locale, ruleName)
+	if !ok {
 		message = "validation by " + ruleName + " not pass."
 	}
-	message = replace(message, params)
-	return errors.New(message)
+	return replace(message, params)
 }
 
 // Convert string or digit type to float
 func parseFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case number:
+		return v.value, nil
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
 	s := parseString(value)
 	return strconv.ParseFloat(s, 64)
 }
@@ -126,15 +350,20 @@ func parseString(value interface{}) string {
 // Parse strings like a -18Y +3h 22s
 // Allowed modifiers: Y, M, D, h, m, s
 func parseDateModifiers(s string) (map[string]int, error) {
-	var modifiers = map[string]int{"Y": 0, "M": 0, "D": 0, "h": 0, "m": 0, "s": 0}
+	var modifiers = map[string]int{"Y": 0, "M": 0, "W": 0, "D": 0, "h": 0, "m": 0, "s": 0}
 
-	matches := regexDateModifier.FindAllStringSubmatch(s, 6)
-	if len(matches) == 0 {
-		return modifiers, errors.New("modifiers not found")
+	for _, token := range strings.Fields(s) {
+		if !regexDateModifiers.MatchString(token) {
+			return modifiers, errors.New("modifiers not found")
+		}
+		for _, item := range regexDateModifier.FindAllStringSubmatch(token, -1) {
+			n, _ := strconv.Atoi(item[1])
+			modifiers[item[2]] += n
+		}
 	}
 
-	for _, item := range matches {
-		modifiers[item[2]], _ = strconv.Atoi(item[1])
+	if len(s) == 0 {
+		return modifiers, errors.New("modifiers not found")
 	}
 
 	return modifiers, nil
@@ -174,6 +403,41 @@ func valueOf(value interface{}) reflect.Value {
 	return refValue
 }
 
+// Join struct path and field name with dot
+func joinPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+// Return struct for recursive validation
+// Pointers are dereferenced, time.Time and unexported fields are skipped
+func nestedStruct(value reflect.Value) (reflect.Value, bool) {
+	value = indirect(value)
+
+	if value.Kind() != reflect.Struct || value.Type() == timeType || !value.CanInterface() {
+		return reflect.Value{}, false
+	}
+
+	return value, true
+}
+
+// Set field to value if types are compatible
+// Numbers are converted to type of field
+func setValue(field reflect.Value, value reflect.Value) {
+	switch {
+	case !value.IsValid():
+		field.Set(reflect.Zero(field.Type()))
+	case value.Type().AssignableTo(field.Type()):
+		field.Set(value)
+	case value.Kind() == field.Kind() || isNumber(value) && isNumber(field):
+		if value.Type().ConvertibleTo(field.Type()) {
+			field.Set(value.Convert(field.Type()))
+		}
+	}
+}
+
 func lenId() {
 
 }
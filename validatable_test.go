@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testInvoice struct {
+	Total float64 `valid:"gt:0"`
+	Items []testLine
+	Email string
+	Phone string
+}
+
+type testLine struct {
+	Price float64 `valid:"gt:0"`
+	Count int
+}
+
+func (i testInvoice) Validate() ErrorMap {
+	var sum float64
+	for _, item := range i.Items {
+		sum += item.Price
+	}
+
+	errs := ErrorMap{"Email": ErrorList{}}
+	if sum != i.Total {
+		errs["Total"] = ErrorList{errors.New("must be equal to sum of items")}
+	}
+	if len(i.Email) == 0 && len(i.Phone) == 0 {
+		errs[""] = ErrorList{errorMessage("required_without_all", "Email", "Phone")}
+	}
+	return errs
+}
+
+func (i *testLine) ValidateCtx(ctx context.Context) ErrorMap {
+	if i.Count == 0 && ctx.Value(testTenantKey{}) == nil {
+		return ErrorMap{"Count": ErrorList{errors.New("is required")}}
+	}
+	return nil
+}
+
+func TestValidateStruct_Validatable(t *testing.T) {
+	invoice := testInvoice{Total: 5, Items: []testLine{{Price: 2, Count: 1}, {Price: 1}}}
+
+	errs := ValidateStruct(invoice)
+
+	if len(errs) != 3 {
+		t.Error("Wrong errors:", errs)
+	}
+	if len(errs["Total"]) != 1 || errs["Total"][0].Error() != "must be equal to sum of items" {
+		t.Error("Error merging struct errors:", errs["Total"])
+	}
+	if len(errs[""]) != 1 || errs[""][0].(*FieldError).Rule != "required_without_all" {
+		t.Error("Error merging errors of struct itself:", errs[""])
+	}
+	if len(errs["Items[1].Count"]) != 1 {
+		t.Error("Error validating nested struct by pointer receiver:", errs)
+	}
+
+	ctx := context.WithValue(context.Background(), testTenantKey{}, "acme")
+	if errs, _ := ValidateStructCtx(ctx, &invoice); errs["Items[1].Count"] != nil {
+		t.Error("Context is not passed to struct:", errs)
+	}
+}
@@ -1,9 +1,10 @@
 package validation
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"net/url"
 	"os"
@@ -17,8 +18,15 @@ import (
 // Custom validation function
 type Validator func(interface{}, OptionList, ...interface{}) error
 
+// Custom validation function what uses context of validation
+// Context can be used for cancellation of I/O and for request-scoped values
+type ContextValidator func(context.Context, interface{}, OptionList, ...interface{}) error
+
 // Custom validation functions map
-type ValidatorMap map[string]Validator
+// It is safe for concurrent use
+type ValidatorMap struct {
+	registry
+}
 
 type DatePlaceholder string
 
@@ -50,6 +58,9 @@ var validators = map[string]Validator{
 	"password":       password,
 	"min":            min,
 	"max":            max,
+	"min_value":      minValue,
+	"max_value":      maxValue,
+	"between":        between,
 	"len":            lenv,
 	"in":             inv,
 	"not_in":         notIn,
@@ -69,8 +80,17 @@ var validators = map[string]Validator{
 	"file_exists":    FileExists,
 }
 
+// List of build-in validators what use context
+// Date rules take current time from clock of context
+var contextValidators = map[string]ContextValidator{
+	"date_gte": dateContextValidator("date_gte", "gte"),
+	"date_lte": dateContextValidator("date_lte", "lte"),
+	"date_gt":  dateContextValidator("date_gt", "gt"),
+	"date_lt":  dateContextValidator("date_lt", "lt"),
+}
+
 // Map of custom validation functions
-var Validators = ValidatorMap{}
+var Validators = &ValidatorMap{}
 
 // Regex validation patterns
 var (
@@ -86,18 +106,59 @@ var (
 )
 
 // Add new validation function
-func (v ValidatorMap) Add(name string, validator Validator) {
-	v[name] = validator
+func (v *ValidatorMap) Add(name string, validator Validator) {
+	v.update(map[string]interface{}{name: validator})
+	resetCache()
+}
+
+// Add new validation function what uses context
+func (v *ValidatorMap) AddContext(name string, validator ContextValidator) {
+	v.update(map[string]interface{}{name: validator})
+	resetCache()
+}
+
+// Get validation function by name
+// Function what uses context is called with background context
+func (v *ValidatorMap) Get(name string) (Validator, bool) {
+	switch validator, _ := v.get(name); validator := validator.(type) {
+	case Validator:
+		return validator, true
+	case ContextValidator:
+		return validator.background(), true
+	}
+	return nil, false
+}
+
+// Get validation function what uses context by name
+func (v *ValidatorMap) GetContext(name string) (ContextValidator, bool) {
+	validator, ok := v.get(name)
+	if !ok {
+		return nil, false
+	}
+	if validator, ok := validator.(ContextValidator); ok {
+		return validator, true
+	}
+	return nil, false
+}
+
+// Return validator what calls function with background context
+func (v ContextValidator) background() Validator {
+	return func(value interface{}, options OptionList, params ...interface{}) error {
+		return v(context.Background(), value, options, params...)
+	}
 }
 
 // Check what validator exists
-func (v ValidatorMap) Has(name string) bool {
-	for n := range v {
+func (v *ValidatorMap) Has(name string) bool {
+	if _, ok := v.get(name); ok {
+		return true
+	}
+	for n := range validators {
 		if name == n {
 			return true
 		}
 	}
-	for n := range validators {
+	for n := range fieldValidators {
 		if name == n {
 			return true
 		}
@@ -128,6 +189,10 @@ func empty(value interface{}, options OptionList, params ...interface{}) error {
 		if size != 0 {
 			return errorMessage("empty")
 		}
+	case reflect.Struct:
+		if t, null, ok := timeOf(val); ok && !null && !t.IsZero() {
+			return errorMessage("empty")
+		}
 	}
 	return nil
 }
@@ -147,7 +212,7 @@ func urlv(value interface{}, options OptionList, params ...interface{}) error {
 	case reflect.String:
 		u, err := url.ParseRequestURI(val.String())
 		if err != nil || len(u.Host) == 0 {
-			return errors.New(Messages["url"])
+			return errorMessage("url")
 		}
 	default:
 		panic(errorWrongType)
@@ -175,6 +240,12 @@ func accepted(value interface{}, options OptionList, params ...interface{}) erro
 // Value kind: String, Array, Slice, Map, Number types
 // It panics if another types given
 func min(value interface{}, options OptionList, params ...interface{}) error {
+	if numericString(value, options) {
+		return numericValidator("min", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+			return x.Cmp(params[0]) >= 0
+		})
+	}
+
 	min, _ := parseFloat(params[0])
 	val := size(value.(reflect.Value))
 	if val < min {
@@ -187,6 +258,12 @@ func min(value interface{}, options OptionList, params ...interface{}) error {
 // Value kind: String, Array, Slice, Map, Number types
 // It panics if another types given
 func max(value interface{}, options OptionList, params ...interface{}) error {
+	if numericString(value, options) {
+		return numericValidator("max", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+			return x.Cmp(params[0]) <= 0
+		})
+	}
+
 	max, _ := parseFloat(params[0])
 	val := size(value.(reflect.Value))
 	if val > max {
@@ -271,12 +348,17 @@ func hasSuffix(value interface{}, options OptionList, params ...interface{}) err
 }
 
 // Value must be matching a specified pattern.
+// Pattern can be a string or *regexp.Regexp
 // Value kind: String
 // It panics if another types given
 func regex(value interface{}, options OptionList, params ...interface{}) error {
-	regex, err := regexp.Compile(params[0].(string))
-	if err != nil {
-		panic(err)
+	regex, ok := params[0].(*regexp.Regexp)
+	if !ok {
+		var err error
+		regex, err = compileRegex(params[0].(string))
+		if err != nil {
+			panic(err)
+		}
 	}
 	return regexValidator(regex, "regex", value.(reflect.Value))
 }
@@ -402,6 +484,12 @@ func contains(value interface{}, options OptionList, params ...interface{}) erro
 // Value kind: Number, String, Slice, Array, Map
 // It panics if another types given
 func gt(value interface{}, options OptionList, params ...interface{}) error {
+	if numericString(value, options) {
+		return numericValidator("gt", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+			return x.Cmp(params[0]) > 0
+		})
+	}
+
 	valueSize := size(value.(reflect.Value))
 	paramSize, _ := parseFloat(params[0])
 	if valueSize <= paramSize {
@@ -416,6 +504,12 @@ func gt(value interface{}, options OptionList, params ...interface{}) error {
 // Value kind: Number, String, Slice, Array, Map
 // It panics if another types given
 func lt(value interface{}, options OptionList, params ...interface{}) error {
+	if numericString(value, options) {
+		return numericValidator("lt", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+			return x.Cmp(params[0]) < 0
+		})
+	}
+
 	valueSize := size(value.(reflect.Value))
 	paramSize, _ := parseFloat(params[0])
 	if valueSize >= paramSize {
@@ -532,7 +626,12 @@ func password(value interface{}, options OptionList, params ...interface{}) erro
 }
 
 // Value must be fit to the specified layout
+// Value of time.Time or struct like sql.NullTime is always valid
 func date(value interface{}, options OptionList, params ...interface{}) error {
+	if _, _, ok := timeOf(value.(reflect.Value)); ok {
+		return nil
+	}
+
 	fn := func(value reflect.Value, params []interface{}) bool {
 		_, err := time.Parse(params[0].(string), value.String())
 		return err == nil
@@ -540,36 +639,54 @@ func date(value interface{}, options OptionList, params ...interface{}) error {
 	return stringValidator("date", value.(reflect.Value), params, fn)
 }
 
+// Value must be a number greater or equal than specified
+// Strings are compared by exact numeric value
+// Value kind: String, Number types
+// It panics if another types given
+func minValue(value interface{}, options OptionList, params ...interface{}) error {
+	return numericValidator("min_value", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+		return x.Cmp(params[0]) >= 0
+	})
+}
+
+// Value must be a number lower or equal than specified
+// Strings are compared by exact numeric value
+// Value kind: String, Number types
+// It panics if another types given
+func maxValue(value interface{}, options OptionList, params ...interface{}) error {
+	return numericValidator("max_value", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+		return x.Cmp(params[0]) <= 0
+	})
+}
+
+// Value must be a number between specified including bounds
+// Strings are compared by exact numeric value
+// Value kind: String, Number types
+// It panics if another types given
+func between(value interface{}, options OptionList, params ...interface{}) error {
+	return numericValidator("between", value.(reflect.Value), params, func(x *big.Rat, params []*big.Rat) bool {
+		return x.Cmp(params[0]) >= 0 && x.Cmp(params[1]) <= 0
+	})
+}
+
 // Value must be a valid date and greater or equal specified
 func dateGte(value interface{}, options OptionList, params ...interface{}) error {
-	fn := func(value reflect.Value, params []interface{}) bool {
-		return dateComparison(value.String(), params, "gte")
-	}
-	return stringValidator("date_gte", value.(reflect.Value), params, fn)
+	return dateValidator(context.Background(), "date_gte", value.(reflect.Value), params, "gte")
 }
 
 // Value must be a valid date and lower or equal specified
 func dateLte(value interface{}, options OptionList, params ...interface{}) error {
-	fn := func(value reflect.Value, params []interface{}) bool {
-		return dateComparison(value.String(), params, "lte")
-	}
-	return stringValidator("date_lte", value.(reflect.Value), params, fn)
+	return dateValidator(context.Background(), "date_lte", value.(reflect.Value), params, "lte")
 }
 
 // Value must be a valid date and greater than specified
 func dateGt(value interface{}, options OptionList, params ...interface{}) error {
-	fn := func(value reflect.Value, params []interface{}) bool {
-		return dateComparison(value.String(), params, "gt")
-	}
-	return stringValidator("date_gt", value.(reflect.Value), params, fn)
+	return dateValidator(context.Background(), "date_gt", value.(reflect.Value), params, "gt")
 }
 
 // Value must be a valid date and lower than specified
 func dateLt(value interface{}, options OptionList, params ...interface{}) error {
-	fn := func(value reflect.Value, params []interface{}) bool {
-		return dateComparison(value.String(), params, "lt")
-	}
-	return stringValidator("date_lt", value.(reflect.Value), params, fn)
+	return dateValidator(context.Background(), "date_lt", value.(reflect.Value), params, "lt")
 }
 
 // Value must be a valid country code in ISO2 format
@@ -606,12 +723,13 @@ func creditCard(value interface{}, options OptionList, params ...interface{}) er
 	return stringValidator("credit_card", value.(reflect.Value), params, fn)
 }
 
+// Value must be a path of existing file
 func FileExists(value interface{}, options OptionList, params ...interface{}) error {
 	fn := func(value reflect.Value, params []interface{}) bool {
 		_, err := os.Stat(value.String())
-		return os.IsExist(err)
+		return err == nil
 	}
-	return stringValidator("credit_card", value.(reflect.Value), params, fn)
+	return stringValidator("file_exists", value.(reflect.Value), params, fn)
 }
 
 // Helper for creating validators what check string codes
@@ -659,36 +777,159 @@ func stringValidator(ruleName string, value reflect.Value, params []interface{},
 	return nil
 }
 
-// Helper for creating date comparision validators
-func dateComparison(value string, params []interface{}, fnName string) bool {
+// Helper for creating validators what compare numbers exactly
+// Not numeric string returns error of "numeric" option
+func numericValidator(ruleName string, value reflect.Value, params []interface{}, fn func(*big.Rat, []*big.Rat) bool) error {
+	x, ok := ratOf(value)
+	if !ok {
+		if value.Kind() == reflect.String {
+			return errorMessage(string(Numeric))
+		}
+		panic(errorWrongType)
+	}
+
+	rats := make([]*big.Rat, len(params))
+	for i, param := range params {
+		rats[i] = paramRat(param)
+	}
+
+	if !fn(x, rats) {
+		return errorMessage(ruleName, params...)
+	}
+	return nil
+}
+
+// Check what string value is compared by numeric value
+func numericString(value interface{}, options OptionList) bool {
+	return options.Has(Numeric) && value.(reflect.Value).Kind() == reflect.String
+}
+
+// Helper for creating date comparison validators
+// Value can be a string in layout of rule, time.Time or struct like sql.NullTime
+// Current time is taken from clock of context in time zone of the third parameter
+func dateValidator(ctx context.Context, ruleName string, value reflect.Value, params []interface{}, fnName string) error {
+	now := clockOf(ctx)()
+	loc := dateLocation(params)
+	if loc != nil {
+		now = now.In(loc)
+	}
+
+	t, null, ok := timeOf(value)
+	if !ok {
+		fn := func(value reflect.Value, params []interface{}) bool {
+			return dateComparison(value.String(), params, fnName, now)
+		}
+		return stringValidator(ruleName, value, params, fn)
+	}
+
+	if !null && !timeComparison(t, params, fnName, now, loc) {
+		return errorMessage(ruleName, params...)
+	}
+	return nil
+}
+
+// Return context validator of date comparison rule
+func dateContextValidator(ruleName string, fnName string) ContextValidator {
+	return func(ctx context.Context, value interface{}, options OptionList, params ...interface{}) error {
+		return dateValidator(ctx, ruleName, value.(reflect.Value), params, fnName)
+	}
+}
+
+// Return time zone of date rule, nil if it is not specified
+// It panics if time zone not exists
+func dateLocation(params []interface{}) *time.Location {
+	if len(params) < 3 {
+		return nil
+	}
+
+	switch param := params[2].(type) {
+	case *time.Location:
+		return param
+	case string:
+		loc, err := loadLocation(param)
+		if err != nil {
+			panic(err)
+		}
+		return loc
+	default:
+		panic(errorWrongType)
+	}
+}
+
+// Compare time with date of placeholder or literal date in layout
+// Calendar dates are compared if layout has no time, instants are compared otherwise
+// Time is converted to time zone of rule before taking its date
+func timeComparison(date time.Time, params []interface{}, fnName string, now time.Time, loc *time.Location) bool {
 	layout := params[0].(string)
 	placeholder := DatePlaceholder(params[1].(string))
 
-	date, err := time.Parse(layout, value)
+	comparedDate, err := GetDateAt(placeholder, now)
 	if err != nil {
-		return false
-	}
-
-	comparedDate, err := GetDate(placeholder)
-	if err == nil {
-		comparedDate, _ = time.Parse(layout, comparedDate.Format(layout))
-	} else {
-		comparedDate, err = time.Parse(layout, string(placeholder))
+		comparedDate, err = time.ParseInLocation(layout, string(placeholder), now.Location())
 		if err != nil {
 			return false
 		}
 	}
 
+	if dateOnly(layout) {
+		if loc != nil {
+			date = date.In(loc)
+		}
+		date, comparedDate = calendarDate(date), calendarDate(comparedDate)
+	}
+
+	return compareResult(compareTimes(date, comparedDate), fnName)
+}
+
+// Check what layout has no time of day
+func dateOnly(layout string) bool {
+	t, err := time.Parse(layout, layoutProbe.Format(layout))
+	return err == nil && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+}
+
+// Time what is used for detecting elements of layout
+var layoutProbe = time.Date(2001, 2, 3, 16, 5, 6, 0, time.UTC)
+
+// Return date of time in its location as midnight in UTC
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Check result of comparison by name of function
+func compareResult(result int, fnName string) bool {
 	switch fnName {
 	case "gte":
-		return date.After(comparedDate) || date.Equal(comparedDate)
+		return result >= 0
 	case "lte":
-		return date.Before(comparedDate) || date.Equal(comparedDate)
+		return result <= 0
 	case "gt":
-		return date.After(comparedDate)
+		return result > 0
 	case "lt":
-		return date.Before(comparedDate)
+		return result < 0
 	default:
 		return false
 	}
 }
+
+// Helper for creating date comparision validators
+func dateComparison(value string, params []interface{}, fnName string, now time.Time) bool {
+	layout := params[0].(string)
+	placeholder := DatePlaceholder(params[1].(string))
+
+	date, err := time.ParseInLocation(layout, value, now.Location())
+	if err != nil {
+		return false
+	}
+
+	comparedDate, err := GetDateAt(placeholder, now)
+	if err == nil {
+		comparedDate, _ = time.ParseInLocation(layout, comparedDate.Format(layout), now.Location())
+	} else {
+		comparedDate, err = time.ParseInLocation(layout, string(placeholder), now.Location())
+		if err != nil {
+			return false
+		}
+	}
+
+	return compareResult(compareTimes(date, comparedDate), fnName)
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
@@ -27,7 +28,10 @@ func TestEmpty(t *testing.T) {
 		{Value: 0, IsValid: true},
 		{Value: 0.0, IsValid: true},
 		{Value: itf, IsValid: true},
+		{Value: time.Time{}, IsValid: true},
+		{Value: sql.NullTime{Time: time.Now()}, IsValid: true},
 		{Value: " ", IsValid: false},
+		{Value: time.Now(), IsValid: false},
 		{Value: "abc", IsValid: false},
 	}
 
@@ -365,6 +369,82 @@ func TestLt(t *testing.T) {
 	testItems(t, lt, items)
 }
 
+func TestMinValue(t *testing.T) {
+	var items = []testItem{
+		{Value: "18", Params: []interface{}{18}, IsValid: true},
+		{Value: "100.5", Params: []interface{}{"18"}, IsValid: true},
+		{Value: 20, Params: []interface{}{"18.5"}, IsValid: true},
+		{Value: uint8T(18), Params: []interface{}{18}, IsValid: true},
+		{Value: "9", Params: []interface{}{18}, IsValid: false},
+		{Value: "-1e3", Params: []interface{}{0}, IsValid: false},
+		{Value: "abc", Params: []interface{}{1}, IsValid: false},
+	}
+
+	testItems(t, minValue, items)
+}
+
+func TestMaxValue(t *testing.T) {
+	var items = []testItem{
+		{Value: "0.1", Params: []interface{}{"0.1"}, IsValid: true},
+		{Value: 1.5, Params: []interface{}{2}, IsValid: true},
+		{Value: "99999999999999999999", Params: []interface{}{"100000000000000000000"}, IsValid: true},
+		{Value: "100000000000000000001", Params: []interface{}{"100000000000000000000"}, IsValid: false},
+		{Value: intT(3), Params: []interface{}{2}, IsValid: false},
+		{Value: "", Params: []interface{}{2}, IsValid: false},
+	}
+
+	testItems(t, maxValue, items)
+}
+
+func TestBetween(t *testing.T) {
+	var items = []testItem{
+		{Value: "1", Params: []interface{}{1, 100}, IsValid: true},
+		{Value: "100", Params: []interface{}{"1", "100"}, IsValid: true},
+		{Value: 50, Params: []interface{}{1, 100}, IsValid: true},
+		{Value: "100.0000000000000001", Params: []interface{}{1, 100}, IsValid: false},
+		{Value: "0", Params: []interface{}{1, 100}, IsValid: false},
+		{Value: "1,5", Params: []interface{}{1, 100}, IsValid: false},
+	}
+
+	testItems(t, between, items)
+}
+
+func TestNumericOption(t *testing.T) {
+	var items = []struct {
+		Value   interface{}
+		Rules   string
+		IsValid bool
+	}{
+		{Value: "9", Rules: "numeric|min:18", IsValid: false},
+		{Value: "18", Rules: "numeric|min:18", IsValid: true},
+		{Value: "100", Rules: "numeric|max:99.99", IsValid: false},
+		{Value: "0.30000000000000001", Rules: "numeric|gt:0.3", IsValid: true},
+		{Value: "0.3", Rules: "numeric|lt:0.30000000000000001", IsValid: true},
+		{Value: "abc", Rules: "numeric", IsValid: false},
+		{Value: "", Rules: "numeric|min:18", IsValid: true},
+		{Value: "9", Rules: "min:18", IsValid: false},
+		{Value: "123", Rules: "max:5", IsValid: true},
+	}
+
+	for _, item := range items {
+		if errs := ValidateValue(item.Value, item.Rules); (len(errs) == 0) != item.IsValid {
+			t.Errorf("Wrong result of %v with «%s»: %v", item.Value, item.Rules, errs)
+		}
+	}
+
+	if errs := ValidateValue("12a", "numeric|min:18"); len(errs) != 1 || errs[0].Error() != "must be a number" {
+		t.Errorf("Wrong numeric error: %v", errs)
+	}
+	r := `[{"code":"min","params":["18"],"message":"must be greater or equal of 18"}]`
+	if errs := ValidateValue("5", "numeric|min:18"); errs.DetailedJSON() != r {
+		t.Errorf("Wrong detailed error of numeric mode: %s", errs.DetailedJSON())
+	}
+	r = `[{"code":"max_value","params":["9.5"],"message":"must be lower or equal of 9.5"}]`
+	if errs := ValidateValue("10", "max_value:9.5"); errs.DetailedJSON() != r {
+		t.Errorf("Wrong detailed error of max_value: %s", errs.DetailedJSON())
+	}
+}
+
 func TestHasKeys(t *testing.T) {
 
 	m := map[string]bool{
@@ -461,11 +541,52 @@ func TestDate(t *testing.T) {
 		{Value: "01-12-2019", Params: []interface{}{"02-01-2006"}, IsValid: true},
 		{Value: "01-52-2019", Params: []interface{}{"02-01-2006"}, IsValid: false},
 		{Value: "fake str", Params: []interface{}{"02-01-2006"}, IsValid: false},
+		{Value: time.Now(), Params: []interface{}{"02-01-2006"}, IsValid: true},
 	}
 
 	testItems(t, date, items)
 }
 
+func TestDate_TimeValues(t *testing.T) {
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	tokyo := time.FixedZone("Tokyo", 9*3600)
+
+	// 2019-12-01 in Tokyo is 2019-11-30 in UTC
+	morning := time.Date(2019, 12, 1, 5, 0, 0, 0, tokyo)
+
+	var items = []struct {
+		Validator Validator
+		Value     interface{}
+		Params    []interface{}
+		IsValid   bool
+	}{
+		{Validator: dateGte, Value: now, Params: []interface{}{"02-01-2006", "today"}, IsValid: true},
+		{Validator: dateGte, Value: &yesterday, Params: []interface{}{"02-01-2006", "today"}, IsValid: false},
+		{Validator: dateLt, Value: sql.NullTime{Time: yesterday, Valid: true}, Params: []interface{}{"02-01-2006", "today"}, IsValid: true},
+		{Validator: dateLt, Value: sql.NullTime{Time: now}, Params: []interface{}{"02-01-2006", "today"}, IsValid: true},
+		{Validator: dateGt, Value: now, Params: []interface{}{"2006-01-02 15:04:05", "-1h"}, IsValid: true},
+		{Validator: dateGte, Value: morning, Params: []interface{}{"2006-01-02", "2019-12-01"}, IsValid: true},
+		{Validator: dateLt, Value: morning, Params: []interface{}{"2006-01-02 15:04", "2019-12-01 00:00"}, IsValid: true},
+		{Validator: dateLte, Value: morning, Params: []interface{}{"2006-01-02", "2019-11-30"}, IsValid: false},
+		{Validator: dateLte, Value: morning, Params: []interface{}{"2006-01-02", "fake"}, IsValid: false},
+	}
+
+	for i, item := range items {
+		err := item.Validator(reflect.ValueOf(item.Value), OptionList{}, item.Params...)
+		if (err == nil) != item.IsValid {
+			t.Errorf("Wrong result of item %d: %v", i, err)
+		}
+	}
+
+	s := struct {
+		Birthday *time.Time `valid:"required|date_lte:2006-01-02,-18Y"`
+	}{Birthday: &now}
+	if errs := ValidateStruct(s); len(errs["Birthday"]) != 1 {
+		t.Error("Error validating time field:", errs)
+	}
+}
+
 func TestDateGte(t *testing.T) {
 	now := time.Now()
 
@@ -601,6 +722,19 @@ func TestCreditCard(t *testing.T) {
 	testItems(t, creditCard, items)
 }
 
+func TestFileExists(t *testing.T) {
+	var items = []testItem{
+		{Value: "validators_test.go", IsValid: true},
+		{Value: "missing_test.go", IsValid: false},
+	}
+
+	testItems(t, FileExists, items)
+
+	if errs := ValidateValue("missing_test.go", "file_exists"); errs[0].(*FieldError).Rule != "file_exists" {
+		t.Errorf("Wrong rule of error: %v", errs)
+	}
+}
+
 func testItems(t *testing.T, fn Validator, items []testItem) {
 	for _, item := range items {
 		err := fn(reflect.ValueOf(item.Value), item.Options, item.Params...)
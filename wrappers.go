@@ -104,6 +104,18 @@ func Max(params ...interface{}) Rule {
 	return Rule{Name: "max", Params: params}
 }
 
+func MinValue(params ...interface{}) Rule {
+	return Rule{Name: "min_value", Params: params}
+}
+
+func MaxValue(params ...interface{}) Rule {
+	return Rule{Name: "max_value", Params: params}
+}
+
+func Between(params ...interface{}) Rule {
+	return Rule{Name: "between", Params: params}
+}
+
 func Len(params ...interface{}) Rule {
 	return Rule{Name: "len", Params: params}
 }
@@ -167,3 +179,75 @@ func HasKeys(params ...interface{}) Rule {
 func HasOnlyKeys(params ...interface{}) Rule {
 	return Rule{Name: "has_only_keys", Params: params}
 }
+
+func EqField(params ...interface{}) Rule {
+	return Rule{Name: "eq_field", Params: params}
+}
+
+func NeField(params ...interface{}) Rule {
+	return Rule{Name: "ne_field", Params: params}
+}
+
+func GtField(params ...interface{}) Rule {
+	return Rule{Name: "gt_field", Params: params}
+}
+
+func GteField(params ...interface{}) Rule {
+	return Rule{Name: "gte_field", Params: params}
+}
+
+func LtField(params ...interface{}) Rule {
+	return Rule{Name: "lt_field", Params: params}
+}
+
+func LteField(params ...interface{}) Rule {
+	return Rule{Name: "lte_field", Params: params}
+}
+
+func RequiredIf(params ...interface{}) Rule {
+	return Rule{Name: "required_if", Params: params}
+}
+
+func RequiredUnless(params ...interface{}) Rule {
+	return Rule{Name: "required_unless", Params: params}
+}
+
+func RequiredWith(params ...interface{}) Rule {
+	return Rule{Name: "required_with", Params: params}
+}
+
+func RequiredWithAll(params ...interface{}) Rule {
+	return Rule{Name: "required_with_all", Params: params}
+}
+
+func RequiredWithout(params ...interface{}) Rule {
+	return Rule{Name: "required_without", Params: params}
+}
+
+func RequiredWithoutAll(params ...interface{}) Rule {
+	return Rule{Name: "required_without_all", Params: params}
+}
+
+func ExcludedIf(params ...interface{}) Rule {
+	return Rule{Name: "excluded_if", Params: params}
+}
+
+func ExcludedUnless(params ...interface{}) Rule {
+	return Rule{Name: "excluded_unless", Params: params}
+}
+
+func ExcludedWith(params ...interface{}) Rule {
+	return Rule{Name: "excluded_with", Params: params}
+}
+
+func ExcludedWithout(params ...interface{}) Rule {
+	return Rule{Name: "excluded_without", Params: params}
+}
+
+func Each(args ...interface{}) Dive {
+	return Dive{Values: args}
+}
+
+func EachKey(args ...interface{}) Dive {
+	return Dive{Keys: args}
+}